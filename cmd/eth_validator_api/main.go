@@ -1,11 +1,14 @@
 package main
 
 import (
+	"os"
+
 	"ethereum-validator-api/internal/cmd"
 )
 
 func main() {
+	// cobra has already printed the error
 	if err := cmd.Execute(); err != nil {
-		panic(err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"ethereum-validator-api/internal/auth"
+	"ethereum-validator-api/internal/rewards"
+)
+
+const (
+	constAPIKeyHeader = "X-API-Key"
+	// constAPIKeyQuery is for the clients that can't set headers, like EventSource
+	constAPIKeyQuery   = "api_key"
+	constAPIKeyContext = "api_key"
+)
+
+// AuthMiddleware checks the API key of the request, the rate limits and the quota of the key.
+// keyring is nil when no API key is required, the limits of the client address still apply then.
+// The config middleware must run first, the cost of the block rewards depends on the requested or configured mode.
+func AuthMiddleware(keyring *auth.Keyring, limiter *auth.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var key *auth.Key
+		if keyring != nil {
+			var err error
+			key, err = keyring.Lookup(requestAPIKey(c))
+			if errors.Is(err, auth.ErrMissingKey) || errors.Is(err, auth.ErrInvalidKey) {
+				c.Header("WWW-Authenticate", "Bearer")
+				abortWithError(c, http.StatusUnauthorized, err.Error())
+				return
+			}
+			c.Set(constAPIKeyContext, key)
+		}
+		decision := limiter.Allow(key, c.ClientIP(), limiter.Cost(costEndpoint(c)))
+		if usage := decision.RateLimit; usage != nil {
+			c.Header("RateLimit-Limit", strconv.Itoa(usage.Limit))
+			c.Header("RateLimit-Remaining", strconv.Itoa(usage.Remaining))
+			c.Header("RateLimit-Reset", headerSeconds(usage.Reset))
+		}
+		if usage := decision.Quota; usage != nil {
+			c.Header("X-Quota-Limit", strconv.Itoa(usage.Limit))
+			c.Header("X-Quota-Remaining", strconv.Itoa(usage.Remaining))
+			c.Header("X-Quota-Reset", headerSeconds(usage.Reset))
+		}
+		if !decision.Allowed {
+			c.Header("Retry-After", headerSeconds(decision.RetryAfter()))
+			message := "rate limit exceeded"
+			if decision.QuotaExceeded {
+				message = "quota exceeded"
+			}
+			abortWithError(c, http.StatusTooManyRequests, message)
+			return
+		}
+		c.Next()
+	}
+}
+
+// requestAPIKey takes the key from the X-API-Key header, a bearer token or the api_key query param.
+func requestAPIKey(c *gin.Context) string {
+	if key := c.GetHeader(constAPIKeyHeader); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return c.Query(constAPIKeyQuery)
+}
+
+// costEndpoint names the endpoint in the costs config: the first segment of the route,
+// with the mode for the block rewards, single or batched, since the beast mode makes many more upstream calls.
+func costEndpoint(c *gin.Context) string {
+	route := strings.TrimPrefix(c.FullPath(), "/")
+	// the namespaces cost the same
+	for _, prefix := range []string{"v1/", "v2/"} {
+		route = strings.TrimPrefix(route, prefix)
+	}
+	endpoint, _, _ := strings.Cut(route, "/")
+	if endpoint == "blockreward" || endpoint == "blockrewards" {
+		mode := rewards.ModeLight
+		if cfg, exists := c.Get("config"); exists {
+			var err error
+			// the handler rejects the unknown modes, they are charged as the configured one
+			if mode, err = requestMode(c, cfg.(*AppConfig)); err != nil {
+				mode = rewards.NormalizeMode(cfg.(*AppConfig).Mode)
+			}
+		}
+		endpoint += "_" + mode
+	}
+	return endpoint
+}
+
+// headerSeconds rounds the duration up to whole seconds.
+func headerSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/auth"
+)
+
+func authRouter(keyring *auth.Keyring, limiter *auth.Limiter) *gin.Engine {
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{Mode: "beast"}))
+	router.Use(AuthMiddleware(keyring, limiter))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/blockreward/:slot", ok)
+	router.GET("/syncduties/:slot", ok)
+	return router
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	keyring, err := auth.NewKeyring([]auth.Key{{Name: "test", Hash: auth.HashKey("secret")}}, "")
+	require.NoError(t, err)
+	limiter := auth.NewLimiter(auth.Config{
+		Window:       time.Minute,
+		KeyRateLimit: 10,
+		QuotaPeriod:  time.Hour,
+		Quota:        25,
+		Costs:        map[string]int{"blockreward_beast": 20, "blockreward_light": 5, "default": 1},
+	})
+	router := authRouter(keyring, limiter)
+	request := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, http.NoBody)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("/syncduties/1", nil)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.JSONEq(t, `{"error":"API key is missing"}`, w.Body.String())
+	w = request("/syncduties/1", http.Header{"X-Api-Key": {"guess"}})
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = request("/blockreward/1", http.Header{"X-Api-Key": {"secret"}})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "10", w.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "9", w.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "60", w.Header().Get("RateLimit-Reset"))
+	require.Equal(t, "25", w.Header().Get("X-Quota-Limit"))
+	require.Equal(t, "5", w.Header().Get("X-Quota-Remaining"))
+
+	// the beast mode rewards cost more than what's left, the sync duties don't
+	w = request("/blockreward/2", http.Header{"Authorization": {"Bearer secret"}})
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.JSONEq(t, `{"error":"quota exceeded"}`, w.Body.String())
+	require.Equal(t, "3600", w.Header().Get("Retry-After"))
+	w = request("/syncduties/1?api_key=secret", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "4", w.Header().Get("X-Quota-Remaining"))
+}
+
+func TestCostEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var endpoints []string
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{Mode: "beast"}))
+	record := func(c *gin.Context) { endpoints = append(endpoints, costEndpoint(c)) }
+	router.GET("/blockreward/:slot", record)
+	router.GET("/v2/blockreward/:slot", record)
+	router.GET("/v1/syncduties/:slot", record)
+	for _, path := range []string{"/blockreward/1", "/v2/blockreward/1?mode=light", "/blockreward/1?mode=turbo", "/v1/syncduties/1"} {
+		req, _ := http.NewRequest(http.MethodGet, path, http.NoBody)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	// the unknown modes are rejected by the handler, they are charged as the configured one
+	require.Equal(t, []string{"blockreward_beast", "blockreward_light", "blockreward_beast", "syncduties"}, endpoints)
+}
+
+func TestAuthMiddlewareIPRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// no keys required, the addresses are still limited
+	router := authRouter(nil, auth.NewLimiter(auth.Config{Window: time.Minute, IPRateLimit: 1}))
+	request := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/syncduties/1", http.NoBody)
+		req.RemoteAddr = "10.0.0.1:4242"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	w := request()
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	require.Empty(t, w.Header().Get("X-Quota-Limit"))
+	w = request()
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.JSONEq(t, `{"error":"rate limit exceeded"}`, w.Body.String())
+	require.Equal(t, "60", w.Header().Get("Retry-After"))
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/rewards"
+	"ethereum-validator-api/models"
+)
+
+// @Summary Get the blobs of a block
+// @Description List the blobs of the block of a slot with their KZG commitments, versioned hashes and proofs, along with the blob gas used,
+// @Description the blob base fee and the blob fees burnt. The proofs come from the blob sidecars and are left out once the node pruned them.
+// @Tags blobs
+// @Produce  json
+// @Param   slot     path    int     true        "Slot Number"
+// @Success 200 {object} models.SlotBlobs
+// @Failure 400 {object} models.Error "slot is in the future / invalid request params"
+// @Failure 404 {object} models.Error "the slot does not exist / was missed"
+// @Failure 500 {object} models.Error "internal server error"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /blocks/{slot}/blobs [get]
+// @Router /v1/blocks/{slot}/blobs [get]
+func GetBlockBlobs(c *gin.Context) {
+	slot, err := strconv.ParseInt(c.Param("slot"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, constInvalidSlotNumber)
+		return
+	}
+	cfg, exists := c.Get("config")
+	if !exists {
+		logger(c).Error("config is missing")
+		respondError(c, http.StatusInternalServerError, "config not found")
+		return
+	}
+	appCfg := cfg.(*AppConfig)
+	client, err := newBeaconClient(c, appCfg)
+	if err != nil {
+		logger(c).WithError(err).Error("could not init beacon client")
+		respondError(c, http.StatusInternalServerError, "failed to init beacon client")
+		return
+	}
+	if client.MapSlotToTimestamp(slot).After(time.Now()) {
+		respondError(c, http.StatusBadRequest, constSlotInFuture)
+		return
+	}
+	if slot < 0 {
+		// there are no blocks before genesis
+		respondError(c, http.StatusNotFound, constBlockNotFound)
+		return
+	}
+	blockResp, err := client.FetchBlockResponse(slot)
+	if errors.Is(err, beaconadapter.ErrNotFound) {
+		respondError(c, http.StatusNotFound, constBlockNotFound)
+		return
+	}
+	if err != nil {
+		logger(c).WithError(err).Errorf("could not fetch block for slot %v", slot)
+		respondError(c, http.StatusInternalServerError, constBlockFetchFailed)
+		return
+	}
+	fees, err := rewards.BlockBlobFees(blockResp)
+	if err != nil {
+		logger(c).WithError(err).Errorf("could not compute the blob fees of slot %v", slot)
+		respondError(c, http.StatusInternalServerError, "failed to compute the blob fees")
+		return
+	}
+	result := models.SlotBlobs{
+		Slot:                slot,
+		Blobs:               []models.Blob{},
+		ExecutionOptimistic: blockResp.ExecutionOptimistic,
+		Finalized:           blockResp.Finalized,
+	}
+	if fees != nil {
+		result.BlobCount = fees.Blobs
+		result.BlobGasUsed = fees.BlobGasUsed
+		result.BlobBaseFee = fees.BaseFee.Int64()
+		result.BlobFees = fees.BurntGwei()
+	}
+	for i, commitment := range blockResp.Data.Message.Body.BlobKzgCommitments {
+		versionedHash, err := rewards.VersionedHash(commitment)
+		if err != nil {
+			logger(c).WithError(err).Errorf("could not hash the blob commitments of slot %v", slot)
+			respondError(c, http.StatusInternalServerError, "failed to hash the blob commitments")
+			return
+		}
+		result.Blobs = append(result.Blobs, models.Blob{Index: int64(i), KzgCommitment: commitment, VersionedHash: versionedHash})
+	}
+	if len(result.Blobs) > 0 {
+		sidecarsResp, err := client.FetchBlobSidecars(slot)
+		if err != nil && !errors.Is(err, beaconadapter.ErrNotFound) {
+			logger(c).WithError(err).Errorf("could not fetch the blob sidecars of slot %v", slot)
+			respondError(c, http.StatusInternalServerError, "failed to fetch the blob sidecars")
+			return
+		}
+		// the sidecars of the blocks older than the retention period are pruned
+		if sidecarsResp != nil {
+			for _, sidecar := range sidecarsResp.Data {
+				index, err := strconv.Atoi(sidecar.Index)
+				if err != nil || index < 0 || index >= len(result.Blobs) || result.Blobs[index].KzgCommitment != sidecar.KzgCommitment {
+					logger(c).Errorf("blob sidecar %v of slot %v doesn't match the block", sidecar.Index, slot)
+					respondError(c, http.StatusInternalServerError, "failed to match the blob sidecars")
+					return
+				}
+				result.Blobs[index].KzgProof = sidecar.KzgProof
+			}
+		}
+	}
+	respond(c, http.StatusOK, result, slotMeta(c, slot, result.Finalized, result.ExecutionOptimistic))
+}
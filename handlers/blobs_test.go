@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/models"
+)
+
+func TestGetBlockBlobs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	commitment := "0xc0" + strings.Repeat("00", 47)
+	// slot 8626180 still has its sidecars, slot 8626190 had them pruned, slot 8000000 is before Deneb
+	blocks := map[string]string{
+		"8626180": `"finalized":true,"data":{"message":{"slot":"8626180","body":{"execution_payload":` +
+			`{"blob_gas_used":"262144","excess_blob_gas":"33384770"},"blob_kzg_commitments":["` + commitment + `","` + commitment + `"]}}}`,
+		"8626190": `"finalized":true,"data":{"message":{"slot":"8626190","body":{"execution_payload":` +
+			`{"blob_gas_used":"131072","excess_blob_gas":"0"},"blob_kzg_commitments":["` + commitment + `"]}}}`,
+		"8000000": `"finalized":true,"data":{"message":{"slot":"8000000","body":{"execution_payload":{}}}}`,
+	}
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		switch {
+		case strings.Contains(r.URL.Path, "/beacon/blocks/"):
+			block, ok := blocks[last]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"version":"deneb",%s}`, block)
+		case r.URL.Path == "/eth/v1/beacon/blob_sidecars/8626180":
+			fmt.Fprintf(w, `{"finalized":true,"data":[{"index":"1","kzg_commitment":"%s","kzg_proof":"0xb1"},`+
+				`{"index":"0","kzg_commitment":"%s","kzg_proof":"0xb0"}]}`, commitment, commitment)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer node.Close()
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: node.URL}))
+	router.GET("/blocks/:slot/blobs", GetBlockBlobs)
+	request := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	versionedHash := "0x010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c444014"
+
+	t.Run("Blobs with their proofs", func(t *testing.T) {
+		w := request("/blocks/8626180/blobs")
+		require.Equal(t, http.StatusOK, w.Code)
+		var result models.SlotBlobs
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		require.Equal(t, models.SlotBlobs{
+			Slot:        8626180,
+			BlobCount:   2,
+			BlobGasUsed: 262144,
+			BlobBaseFee: 22026,
+			BlobFees:    5,
+			Blobs: []models.Blob{
+				{Index: 0, KzgCommitment: commitment, VersionedHash: versionedHash, KzgProof: "0xb0"},
+				{Index: 1, KzgCommitment: commitment, VersionedHash: versionedHash, KzgProof: "0xb1"},
+			},
+			Finalized: true,
+		}, result)
+	})
+
+	t.Run("Pruned sidecars", func(t *testing.T) {
+		w := request("/blocks/8626190/blobs")
+		require.Equal(t, http.StatusOK, w.Code)
+		var result models.SlotBlobs
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		require.Equal(t, int64(1), result.BlobBaseFee)
+		require.Equal(t, []models.Blob{{Index: 0, KzgCommitment: commitment, VersionedHash: versionedHash}}, result.Blobs)
+	})
+
+	t.Run("Block before Deneb", func(t *testing.T) {
+		w := request("/blocks/8000000/blobs")
+		require.Equal(t, http.StatusOK, w.Code)
+		var result models.SlotBlobs
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		require.Equal(t, models.SlotBlobs{Slot: 8000000, Blobs: []models.Blob{}, Finalized: true}, result)
+	})
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{"Missed slot (404)", "/blocks/8626181/blobs", http.StatusNotFound},
+		{"Invalid slot (400)", "/blocks/abc/blobs", http.StatusBadRequest},
+		{"Future slot (400)", "/blocks/4503137824400/blobs", http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedStatus, request(tc.path).Code)
+		})
+	}
+}
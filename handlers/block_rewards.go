@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/models"
+)
+
+const (
+	// constDefaultPageSize is one epoch
+	constDefaultPageSize = 32
+	// constMaxBatchSlots caps the slots computed for one request
+	constMaxBatchSlots = 100
+	// constDefaultBatchWorkers is the number of slots of a batch computed at the same time
+	constDefaultBatchWorkers = 4
+)
+
+// @Summary Get the rewards of a slot range
+// @Description Get the rewards of the slots in [from_slot, to_slot], a page of at most limit slots at a time.
+// @Description The slots that fail, the missed ones included, carry their error instead of failing the page.
+// @Tags rewards
+// @Produce  json,text/csv,application/x-ndjson
+// @Param   from_slot query   int     true        "First slot of the range"
+// @Param   to_slot   query   int     true        "Last slot of the range"
+// @Param   limit     query   int     false       "Slots per page, 32 by default, at most 100"
+// @Param   mode      query   string  false       "Reward mode, server.mode by default" Enums(light, full)
+// @Param   currency  query   string  false       "Fiat currency to value the reward in, e.g. usd, when prices are enabled"
+// @Param   format    query   string  false       "Answer format, negotiated from the Accept header by default" Enums(json, csv, ndjson)
+// @Success 200 {object} models.BlockRewards
+// @Failure 400 {object} models.Error "slot is in the future / invalid request params"
+// @Failure 403 {object} models.Error "the API key may not request the mode"
+// @Failure 500 {object} models.Error "internal server error"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /blockrewards [get]
+// @Router /v1/blockrewards [get]
+func GetBlockRewards(c *gin.Context) {
+	from, err := strconv.ParseInt(c.Query("from_slot"), 10, 64)
+	if err != nil || from < 0 {
+		respondError(c, http.StatusBadRequest, constInvalidSlotNumber)
+		return
+	}
+	to, err := strconv.ParseInt(c.Query("to_slot"), 10, 64)
+	if err != nil || to < from {
+		respondError(c, http.StatusBadRequest, constInvalidSlotNumber)
+		return
+	}
+	limit := constDefaultPageSize
+	if value, ok := c.GetQuery("limit"); ok {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > constMaxBatchSlots {
+			respondError(c, http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(constMaxBatchSlots))
+			return
+		}
+	}
+	format, ok := responseFormat(c)
+	if !ok {
+		return
+	}
+	appCfg, source, ok := batchSource(c)
+	if !ok {
+		return
+	}
+	if source.beacon.MapSlotToTimestamp(to).After(time.Now()) {
+		respondError(c, http.StatusBadRequest, constSlotInFuture)
+		return
+	}
+	last := min(to, from+int64(limit)-1)
+	slots := make([]int64, 0, last-from+1)
+	for slot := from; slot <= last; slot++ {
+		slots = append(slots, slot)
+	}
+	clearWriteDeadline(c)
+	batch, err := source.batch(c.Request.Context(), slots, appCfg.BatchWorkers)
+	if err != nil {
+		logger(c).WithError(err).Warnf("gave up the rewards of %v-%v", from, last)
+		c.Abort()
+		return
+	}
+	result := models.BlockRewards{Rewards: batch}
+	if last < to {
+		next := last + 1
+		result.NextFromSlot = &next
+	}
+	respondBlockRewards(c, format, result)
+}
+
+// @Summary Get the rewards of a list of slots
+// @Description Get the rewards of up to 100 slots, in the order they are listed, without the duplicates.
+// @Description The slots that fail, the missed and the future ones included, carry their error instead of failing the batch.
+// @Tags rewards
+// @Accept  json
+// @Produce  json,text/csv,application/x-ndjson
+// @Param   request  body    models.BlockRewardsRequest  true  "Slots"
+// @Param   mode     query   string  false       "Reward mode, server.mode by default" Enums(light, full)
+// @Param   currency query   string  false       "Fiat currency to value the reward in, e.g. usd, when prices are enabled"
+// @Param   format   query   string  false       "Answer format, negotiated from the Accept header by default" Enums(json, csv, ndjson)
+// @Success 200 {object} models.BlockRewards
+// @Failure 400 {object} models.Error "invalid request params"
+// @Failure 403 {object} models.Error "the API key may not request the mode"
+// @Failure 500 {object} models.Error "internal server error"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /blockrewards [post]
+// @Router /v1/blockrewards [post]
+func PostBlockRewards(c *gin.Context) {
+	var request models.BlockRewardsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	slots := make([]int64, 0, len(request.Slots))
+	seen := make(map[int64]bool, len(request.Slots))
+	for _, slot := range request.Slots {
+		if !seen[slot] {
+			seen[slot] = true
+			slots = append(slots, slot)
+		}
+	}
+	if len(slots) == 0 || len(slots) > constMaxBatchSlots {
+		respondError(c, http.StatusBadRequest, "between 1 and "+strconv.Itoa(constMaxBatchSlots)+" slots are required")
+		return
+	}
+	format, ok := responseFormat(c)
+	if !ok {
+		return
+	}
+	appCfg, source, ok := batchSource(c)
+	if !ok {
+		return
+	}
+	clearWriteDeadline(c)
+	batch, err := source.batch(c.Request.Context(), slots, appCfg.BatchWorkers)
+	if err != nil {
+		logger(c).WithError(err).Warnf("gave up the rewards of %v slots", len(slots))
+		c.Abort()
+		return
+	}
+	respondBlockRewards(c, format, models.BlockRewards{Rewards: batch})
+}
+
+// batchSource sets up the reward source of a batch request, or answers the request when it can't be.
+func batchSource(c *gin.Context) (*AppConfig, *rewardSource, bool) {
+	cfg, exists := c.Get("config")
+	if !exists {
+		logger(c).Error("config is missing")
+		respondError(c, http.StatusInternalServerError, "config not found")
+		return nil, nil, false
+	}
+	appCfg := cfg.(*AppConfig)
+	mode, ok := allowedMode(c, appCfg)
+	if !ok {
+		return nil, nil, false
+	}
+	currency, ok := fiatCurrency(c, appCfg)
+	if !ok {
+		return nil, nil, false
+	}
+	source, err := newRewardSource(c, appCfg, mode)
+	if err != nil {
+		logger(c).WithError(err).Error("failed to init the upstream clients")
+		respondError(c, http.StatusInternalServerError, "failed to init the upstream clients")
+		return nil, nil, false
+	}
+	source.currency = currency
+	// the blocks of a batch share their MEV lookups
+	source.rewards = source.rewards.ForBatch()
+	return appCfg, source, true
+}
+
+// batch computes the rewards of the slots with a pool of workers. The results are in the order of the slots.
+// It fails only when ctx is done before all the slots are computed.
+func (s *rewardSource) batch(ctx context.Context, slots []int64, workers int) ([]models.SlotBlockReward, error) {
+	results := make([]models.SlotBlockReward, len(slots))
+	if err := parallelContext(ctx, len(slots), workers, func(i int) {
+		results[i] = s.slotReward(ctx, slots[i])
+	}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// parallelContext calls fn for 0 to n-1 with a pool of workers and returns once all calls returned.
+// Once ctx is done no more calls are made, the indices left are skipped and its error is returned.
+func parallelContext(ctx context.Context, n, workers int, fn func(i int)) error {
+	if workers < 1 {
+		workers = constDefaultBatchWorkers
+	}
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(indices)
+	wg.Wait()
+	return ctx.Err()
+}
+
+// clearWriteDeadline lifts the server write timeout for a request computing many slots, which takes as long as
+// its slots do; it stops early when the client goes away instead.
+func clearWriteDeadline(c *gin.Context) {
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		logger(c).WithError(err).Debug("failed to clear the write deadline")
+	}
+}
+
+func (s *rewardSource) slotReward(ctx context.Context, slot int64) models.SlotBlockReward {
+	result := models.SlotBlockReward{Slot: slot}
+	reward, _, err := s.reward(ctx, slot)
+	if err != nil {
+		code, message := rewardError(err)
+		if code == http.StatusInternalServerError {
+			logrus.WithContext(ctx).WithError(err).Errorf("failed for slot %v in mode %v", slot, s.mode)
+		}
+		result.Error = message
+		result.Missed = errors.Is(err, beaconadapter.ErrNotFound)
+		return result
+	}
+	s.value(ctx, reward)
+	result.Reward = blockReward(reward)
+	return result
+}
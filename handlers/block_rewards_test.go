@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/models"
+)
+
+func TestBlockRewards(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+	for _, slot := range []int64{4700013, 4700014} {
+		require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: slot, Mode: "light", Reward: slot - 4700000, Finalized: true}))
+	}
+	// slot 4700015 was missed, the node fails on the others
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/4700015") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer node.Close()
+
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: node.URL, Mode: "light", Store: s, BatchWorkers: 2}))
+	router.GET("/blockrewards", GetBlockRewards)
+	router.POST("/blockrewards", PostBlockRewards)
+	request := func(method, path, body string) (int, *models.BlockRewards) {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			return w.Code, nil
+		}
+		var result models.BlockRewards
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		return w.Code, &result
+	}
+
+	code, result := request("GET", "/blockrewards?from_slot=4700013&to_slot=4700017&limit=3", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, result.Rewards, 3)
+	require.Equal(t, models.SlotBlockReward{Slot: 4700013, Reward: &models.BlockReward{Reward: 13, Finalized: true, Mode: "light"}}, result.Rewards[0])
+	require.EqualValues(t, 14, result.Rewards[1].Reward.Reward)
+	require.Equal(t, models.SlotBlockReward{Slot: 4700015, Missed: true, Error: constBlockNotFound}, result.Rewards[2])
+	require.NotNil(t, result.NextFromSlot)
+	require.EqualValues(t, 4700016, *result.NextFromSlot)
+
+	code, result = request("GET", "/blockrewards?from_slot=4700016&to_slot=4700017&limit=3", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []models.SlotBlockReward{
+		{Slot: 4700016, Error: constBlockFetchFailed},
+		{Slot: 4700017, Error: constBlockFetchFailed},
+	}, result.Rewards)
+	require.Nil(t, result.NextFromSlot)
+
+	code, _ = request("GET", "/blockrewards?from_slot=4700013&to_slot=4700012", "")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = request("GET", "/blockrewards?from_slot=4700013&to_slot=4700017&limit=101", "")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = request("GET", "/blockrewards?from_slot=4700013&to_slot=4503137824400", "")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	// the future slots of a list fail alone, the duplicates are left out
+	code, result = request("POST", "/blockrewards", `{"slots":[4700014,4503137824400,4700014,4700013]}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, result.Rewards, 3)
+	require.EqualValues(t, 14, result.Rewards[0].Reward.Reward)
+	require.Equal(t, models.SlotBlockReward{Slot: 4503137824400, Error: constSlotInFuture}, result.Rewards[1])
+	require.EqualValues(t, 13, result.Rewards[2].Reward.Reward)
+
+	code, _ = request("POST", "/blockrewards", `{"slots":[]}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = request("POST", "/blockrewards?mode=turbo", `{"slots":[4700013]}`)
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestParallelCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	err := parallelContext(ctx, 100, 2, func(i int) {
+		// the client goes away during the third slot
+		if calls.Add(1) == 3 {
+			cancel()
+		}
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	// the calls under way finish, no new ones start
+	require.LessOrEqual(t, calls.Load(), int32(4))
+
+	calls.Store(0)
+	require.NoError(t, parallelContext(context.Background(), 100, 2, func(i int) { calls.Add(1) }))
+	require.Equal(t, int32(100), calls.Load())
+}
+
+func TestClearWriteDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/slow", func(c *gin.Context) {
+		clearWriteDeadline(c)
+		time.Sleep(300 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	})
+	server := httptest.NewUnstartedServer(router)
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/slow")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "done", string(body))
+}
@@ -2,12 +2,39 @@ package handlers
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/health"
+	"ethereum-validator-api/internal/prices"
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/internal/stream"
+	"ethereum-validator-api/internal/watch"
 )
 
 type AppConfig struct {
-	BaseURL       string `json:"base_url"`
-	EthScanAPIKey string `json:"eth_scan_api_key"`
-	Mode          string `json:"mode"`
+	BaseURL string `json:"base_url"`
+	// Beacon is the beacon client shared by the requests, so what it learns about the node lasts;
+	// one is made from BaseURL for every request when nil
+	Beacon        *beaconadapter.BeaconClient `json:"-"`
+	EthScanAPIKey string                      `json:"eth_scan_api_key"`
+	Mode          string                      `json:"mode"`
+	// NodeName names the upstream node in the v2 meta, without the credentials its URL may hold
+	NodeName string `json:"node_name"`
+	// BatchWorkers is the number of slots of a batch request computed at the same time
+	BatchWorkers int `json:"batch_workers"`
+	// Registry resolves validator indices and pubkeys locally, nil when disabled
+	Registry *beaconadapter.Registry `json:"-"`
+	// Store keeps the results for finalized slots, nil when disabled
+	Store *store.Store `json:"-"`
+	// Stream hands out the rewards of new head blocks, nil when the head follower is off
+	Stream *stream.Hub `json:"-"`
+	// Watchlist holds the validators the alerting rules are checked for, nil when disabled
+	Watchlist *watch.Watchlist `json:"-"`
+	// Health probes the upstreams for the readiness and status endpoints
+	Health *health.Checker `json:"-"`
+	// Prices values the rewards in fiat currencies, nil when disabled
+	Prices prices.Source `json:"-"`
 }
 
 func ConfigMiddleware(cfg *AppConfig) gin.HandlerFunc {
@@ -16,3 +43,20 @@ func ConfigMiddleware(cfg *AppConfig) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// logger is the log entry of the request, the tracing hook adds the ids of its span.
+func logger(c *gin.Context) *logrus.Entry {
+	return logrus.WithContext(c.Request.Context())
+}
+
+// newBeaconClient returns a beacon client sending its requests in the context of the request.
+func newBeaconClient(c *gin.Context, appCfg *AppConfig) (*beaconadapter.BeaconClient, error) {
+	client := appCfg.Beacon
+	if client == nil {
+		var err error
+		if client, err = beaconadapter.NewBeaconClient(appCfg.BaseURL, nil); err != nil {
+			return nil, err
+		}
+	}
+	return client.WithContext(c.Request.Context()), nil
+}
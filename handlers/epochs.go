@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/rewards"
+	"ethereum-validator-api/models"
+)
+
+const constInvalidEpochNumber = "Invalid epoch number"
+
+// @Summary Get epoch summary
+// @Description Sum up the epoch: proposed and missed slots, EL rewards of the proposers (fees, burns, MEV payments),
+// @Description CL rewards of the proposers, MEV-boost share, attestation participation, finality and sync committee period.
+// @Description The participation counts the attestations included up to the end of the next epoch, it is provisional until then.
+// @Tags epochs
+// @Produce  json
+// @Param   epoch    path    int     true        "Epoch Number"
+// @Success 200 {object} models.EpochSummary
+// @Failure 400 {object} models.Error "epoch is in the future / invalid request params"
+// @Failure 500 {object} models.Error "internal server error"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /epochs/{epoch} [get]
+// @Router /v1/epochs/{epoch} [get]
+func GetEpoch(c *gin.Context) {
+	epoch, err := strconv.ParseInt(c.Param("epoch"), 10, 64)
+	if err != nil || epoch < 0 {
+		respondError(c, http.StatusBadRequest, constInvalidEpochNumber)
+		return
+	}
+	cfg, exists := c.Get("config")
+	if !exists {
+		logger(c).Error("config is missing")
+		respondError(c, http.StatusInternalServerError, "config not found")
+		return
+	}
+	appCfg := cfg.(*AppConfig)
+	// the EL rewards are the light mode ones, the CL rewards come from the node
+	source, err := newRewardSource(c, appCfg, rewards.ModeLight)
+	if err != nil {
+		logger(c).WithError(err).Error("failed to init the upstream clients")
+		respondError(c, http.StatusInternalServerError, "failed to init the upstream clients")
+		return
+	}
+	source.rewards = source.rewards.ForBatch()
+	// compared in slots, the timestamps of far future slots overflow
+	if epoch > source.beacon.MapTimestampToSlot(time.Now())/beaconadapter.EthereumSlotsPerEpoch {
+		respondError(c, http.StatusBadRequest, "Epoch is in the future")
+		return
+	}
+	// the blocks of two epochs and the rewards of one take longer than the server write timeout
+	clearWriteDeadline(c)
+	summary, optimistic, err := source.epochSummary(c.Request.Context(), epoch, appCfg.BatchWorkers)
+	if err != nil && c.Request.Context().Err() != nil {
+		logger(c).WithError(err).Warnf("gave up the summary of epoch %v", epoch)
+		c.Abort()
+		return
+	}
+	if err != nil {
+		logger(c).WithError(err).Errorf("could not sum up epoch %v", epoch)
+		respondError(c, http.StatusInternalServerError, "failed to sum up the epoch")
+		return
+	}
+	meta := nodeMeta(c)
+	meta.Epoch = &summary.Epoch
+	meta.Finalized = &summary.Finalized
+	meta.ExecutionOptimistic = &optimistic
+	respond(c, http.StatusOK, summary, meta)
+}
+
+// epochSummary fetches the blocks of the epoch and of the next one, which include the rest of its attestations,
+// and sums up the epoch. It also tells whether any of its blocks is optimistic.
+func (s *rewardSource) epochSummary(ctx context.Context, epoch int64, workers int) (*models.EpochSummary, bool, error) {
+	from := epoch * beaconadapter.EthereumSlotsPerEpoch
+	to := from + beaconadapter.EthereumSlotsPerEpoch - 1
+	last := min(to+beaconadapter.EthereumSlotsPerEpoch, s.beacon.MapTimestampToSlot(time.Now()))
+	blocks := make([]*beaconadapter.BlockResponse, last-from+1)
+	errs := make([]error, len(blocks))
+	if err := parallelContext(ctx, len(blocks), workers, func(i int) {
+		blocks[i], errs[i] = s.beacon.FetchBlockResponse(from + int64(i))
+	}); err != nil {
+		return nil, false, err
+	}
+	for i, err := range errs {
+		if err != nil && !errors.Is(err, beaconadapter.ErrNotFound) {
+			return nil, false, fmt.Errorf("failed to fetch the block of slot %d: %w", from+int64(i), err)
+		}
+	}
+
+	summary := &models.EpochSummary{
+		Epoch:               epoch,
+		FromSlot:            from,
+		ToSlot:              to,
+		MissedSlots:         make([]int64, 0),
+		SyncCommitteePeriod: epoch / beaconadapter.EpochsPerSyncCommitteePeriod,
+	}
+	var proposed []*beaconadapter.BlockResponse
+	for slot := from; slot <= min(to, last); slot++ {
+		if block := blocks[slot-from]; block != nil {
+			proposed = append(proposed, block)
+		} else {
+			summary.MissedSlots = append(summary.MissedSlots, slot)
+		}
+	}
+	breakdowns := make([]*models.RewardBreakdown, len(proposed))
+	consensusRewards := make([]int64, len(proposed))
+	errs = make([]error, len(proposed))
+	if err := parallelContext(ctx, len(proposed), workers, func(i int) {
+		breakdowns[i], consensusRewards[i], errs[i] = s.blockSummary(ctx, proposed[i])
+	}); err != nil {
+		return nil, false, err
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, false, err
+	}
+
+	optimistic := false
+	for i, breakdown := range breakdowns {
+		summary.TransactionFees += breakdown.TransactionFees
+		summary.BurntFees += breakdown.BurntFees
+		summary.MevPayments += breakdown.MevPayment
+		summary.Blobs += breakdown.Blobs
+		summary.BlobFees += breakdown.BlobFees
+		summary.ExecutionRewards += breakdown.Reward
+		summary.ConsensusProposerRewards += consensusRewards[i]
+		if breakdown.Mev {
+			summary.MevBlocks++
+		}
+		optimistic = optimistic || proposed[i].ExecutionOptimistic
+	}
+	summary.ProposedSlots = len(proposed)
+	if summary.ProposedSlots > 0 {
+		summary.MevBoostShare = float64(summary.MevBlocks) / float64(summary.ProposedSlots)
+	}
+
+	var included []*beaconadapter.BlockResponse
+	for _, block := range blocks {
+		if block != nil {
+			included = append(included, block)
+		}
+	}
+	participation, err := rewards.Participation(epoch, included)
+	if err != nil {
+		return nil, false, err
+	}
+	summary.ParticipationRate = participation
+	finalizedSlot, err := s.beacon.FinalizedSlot()
+	if err != nil {
+		return nil, false, err
+	}
+	summary.Finalized = from <= finalizedSlot
+	return summary, optimistic, nil
+}
+
+// blockSummary returns the EL reward breakdown, stored or computed, and the CL reward of the proposer of the block.
+func (s *rewardSource) blockSummary(ctx context.Context, block *beaconadapter.BlockResponse) (*models.RewardBreakdown, int64, error) {
+	slot, err := strconv.ParseInt(block.Data.Message.Slot, 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse the block slot: %w", err)
+	}
+	breakdown := s.stored(ctx, slot)
+	if breakdown == nil {
+		if breakdown, err = s.compute(ctx, block); err != nil {
+			return nil, 0, fmt.Errorf("failed to compute the reward of slot %d: %w", slot, err)
+		}
+	}
+	blockRewards, err := s.beacon.FetchBlockRewardsResponse(slot)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch the block rewards of slot %d: %w", slot, err)
+	}
+	consensusReward, err := strconv.ParseInt(blockRewards.Data.Total, 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse the block rewards of slot %d: %w", slot, err)
+	}
+	return breakdown, consensusReward, nil
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/models"
+)
+
+// epochStandIn serves the blocks of epochs 10 and 11, slot 321 was missed.
+// The block of slot 322 carries the attestations of epoch 10: 3 of the 4 members of the committee.
+func epochStandIn() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		switch {
+		case strings.Contains(r.URL.Path, "/finality_checkpoints"):
+			fmt.Fprint(w, `{"data":{"finalized":{"epoch":"20"}}}`)
+		case strings.HasPrefix(r.URL.Path, "/eth/v1/beacon/rewards/blocks/"):
+			fmt.Fprint(w, `{"data":{"proposer_index":"1","total":"30000000"}}`)
+		case strings.HasPrefix(r.URL.Path, "/eth/v2/beacon/blocks/") && id != "321":
+			attestations := ""
+			if id == "322" {
+				attestations = `{"aggregation_bits":"0x17","data":{"slot":"320","index":"0","target":{"epoch":"10"}}}`
+			}
+			fmt.Fprintf(w, `{"finalized":true,"data":{"message":{"slot":"%s","proposer_index":"1",`+
+				`"body":{"attestations":[%s],"execution_payload":{"block_number":"1"}}}}}`, id, attestations)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGetEpoch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	node := epochStandIn()
+	defer node.Close()
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+	// the EL rewards are all stored, the execution node isn't needed
+	for slot := int64(320); slot <= 351; slot++ {
+		require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{
+			Slot: slot, Mode: "light", TransactionFees: 30, BurntFees: 10, MevPayment: 5, Reward: 25,
+			Mev: slot%4 == 0, Finalized: true,
+		}))
+	}
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: node.URL, Store: s}))
+	router.GET("/epochs/:epoch", GetEpoch)
+	request := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, http.NoBody)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("/epochs/10")
+	require.Equal(t, http.StatusOK, w.Code)
+	var summary models.EpochSummary
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &summary))
+	require.Equal(t, models.EpochSummary{
+		Epoch:                    10,
+		FromSlot:                 320,
+		ToSlot:                   351,
+		ProposedSlots:            31,
+		MissedSlots:              []int64{321},
+		TransactionFees:          31 * 30,
+		BurntFees:                31 * 10,
+		MevPayments:              31 * 5,
+		ExecutionRewards:         31 * 25,
+		ConsensusProposerRewards: 31 * 30000000,
+		MevBlocks:                8,
+		MevBoostShare:            8.0 / 31.0,
+		ParticipationRate:        0.75,
+		Finalized:                true,
+		SyncCommitteePeriod:      0,
+	}, summary)
+
+	w = request("/epochs/ten")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = request("/epochs/99999999999")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetEpochCanceled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	standIn := epochStandIn()
+	defer standIn.Close()
+	var blockRequests atomic.Int32
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/beacon/blocks/") {
+			blockRequests.Add(1)
+		}
+		standIn.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer node.Close()
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: node.URL}))
+	router.GET("/epochs/:epoch", GetEpoch)
+
+	// the client is gone before the blocks are fetched
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/epochs/10", http.NoBody)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Empty(t, w.Body.String())
+	require.Zero(t, blockRequests.Load())
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"ethereum-validator-api/internal/export"
+	"ethereum-validator-api/models"
+)
+
+// constFormatJSON is the default format of the answers, the others are the tabular export formats
+const constFormatJSON export.Format = "json"
+
+// responseFormat is the format of the format query param, else the one negotiated from the Accept header,
+// JSON by default. It answers the request when the format isn't served.
+func responseFormat(c *gin.Context) (export.Format, bool) {
+	if name, ok := c.GetQuery("format"); ok {
+		if export.Format(name) == constFormatJSON {
+			return constFormatJSON, true
+		}
+		format, err := export.ParseFormat(name)
+		if err != nil || format == export.Parquet {
+			respondError(c, http.StatusBadRequest, "format must be json, csv or ndjson")
+			return "", false
+		}
+		return format, true
+	}
+	switch c.NegotiateFormat(binding.MIMEJSON, export.CSV.ContentType(), export.NDJSON.ContentType()) {
+	case export.CSV.ContentType():
+		return export.CSV, true
+	case export.NDJSON.ContentType():
+		return export.NDJSON, true
+	}
+	return constFormatJSON, true
+}
+
+// respondBlockRewards writes the rewards in the format. The tabular formats carry the rows alone,
+// the next page of a range is linked in the Link header.
+func respondBlockRewards(c *gin.Context, format export.Format, result models.BlockRewards) {
+	if format == constFormatJSON {
+		respond(c, http.StatusOK, result, nodeMeta(c))
+		return
+	}
+	if result.NextFromSlot != nil {
+		next := *c.Request.URL
+		query := next.Query()
+		query.Set("from_slot", strconv.FormatInt(*result.NextFromSlot, 10))
+		next.RawQuery = query.Encode()
+		// added, the deprecated routes already link their successor
+		c.Writer.Header().Add("Link", "<"+next.String()+`>; rel="next"`)
+	}
+	rows := make([]models.SlotBlockRewardRow, len(result.Rewards))
+	for i := range result.Rewards {
+		rows[i] = result.Rewards[i].Row()
+	}
+	c.Header("Content-Type", format.ContentType())
+	c.Status(http.StatusOK)
+	w, err := export.NewWriter[models.SlotBlockRewardRow](c.Writer, format)
+	if err == nil {
+		err = w.Write(rows...)
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		// the status is sent already
+		logger(c).WithError(err).Errorf("failed to write the %s rows", format)
+	}
+}
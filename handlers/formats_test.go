@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/models"
+)
+
+func TestBlockRewardsFormats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+	require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: 4700013, Mode: "light", Mev: true, Reward: 13, Finalized: true}))
+	// slot 4700014 was missed
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer node.Close()
+
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: node.URL, Mode: "light", Store: s}))
+	router.GET("/blockrewards", GetBlockRewards)
+	router.POST("/blockrewards", PostBlockRewards)
+	request := func(method, path, accept, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("GET", "/blockrewards?from_slot=4700013&to_slot=4700020&limit=2", "text/csv", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+	require.Equal(t, "slot,missed,error,mev,reward,execution_optimistic,finalized,mode,fiat_currency,fiat_date,fiat_price,fiat_reward\n"+
+		"4700013,false,,true,13,false,true,light,,,,\n"+
+		"4700014,true,"+constBlockNotFound+",,,,,,,,,\n", w.Body.String())
+	require.Equal(t, `</blockrewards?from_slot=4700015&limit=2&to_slot=4700020>; rel="next"`, w.Header().Get("Link"))
+
+	// a deprecated root route links both its successor and the next page
+	root := gin.New()
+	root.Use(ConfigMiddleware(&AppConfig{BaseURL: node.URL, Mode: "light", Store: s}), DeprecationMiddleware("/v1"))
+	root.GET("/blockrewards", GetBlockRewards)
+	req, _ := http.NewRequest("GET", "/blockrewards?from_slot=4700013&to_slot=4700020&limit=2&format=csv", http.NoBody)
+	w = httptest.NewRecorder()
+	root.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []string{
+		`</v1/blockrewards>; rel="successor-version"`,
+		`</blockrewards?format=csv&from_slot=4700015&limit=2&to_slot=4700020>; rel="next"`,
+	}, w.Header().Values("Link"))
+
+	// the format param wins over the Accept header
+	w = request("POST", "/blockrewards?format=ndjson", "text/csv", `{"slots":[4700013,4700014]}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	require.Equal(t, `{"slot":4700013,"missed":false,"error":"","mev":true,"reward":13,"execution_optimistic":false,"finalized":true,"mode":"light","fiat":null}`+"\n"+
+		`{"slot":4700014,"missed":true,"error":"`+constBlockNotFound+`","mev":null,"reward":null,"execution_optimistic":null,"finalized":null,"mode":"","fiat":null}`+"\n",
+		w.Body.String())
+
+	w = request("GET", "/blockrewards?from_slot=4700013&to_slot=4700013", "application/json, */*", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "application/json"))
+
+	w = request("GET", "/blockrewards?from_slot=4700013&to_slot=4700013&format=parquet", "", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"ethereum-validator-api/models"
+)
+
+// @Summary Liveness probe
+// @Description Answers as long as the process is up, whatever the state of the upstreams
+// @Tags health
+// @Produce  json
+// @Success 200 {object} map[string]string
+// @Router /healthz [get]
+func GetHealthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// @Summary Readiness probe
+// @Description Ready when the beacon node is reachable and synced with a recent head, and the execution node is reachable
+// @Tags health
+// @Produce  json
+// @Success 200 {object} models.Readiness
+// @Failure 503 {object} models.Readiness "not ready, with the reasons"
+// @Failure 500 {object} models.Error "internal server error"
+// @Router /readyz [get]
+func GetReadyz(c *gin.Context) {
+	appCfg, ok := healthConfig(c)
+	if !ok {
+		return
+	}
+	status := appCfg.Health.Check(c.Request.Context())
+	code := http.StatusOK
+	if !status.Ready {
+		logger(c).Warnf("not ready: %v", status.Reasons)
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, models.Readiness{Ready: status.Ready, Reasons: status.Reasons})
+}
+
+// @Summary Upstream status
+// @Description Head, sync distance, latency and last error of every upstream node, checked on request
+// @Tags health
+// @Produce  json
+// @Success 200 {object} models.Status
+// @Failure 500 {object} models.Error "internal server error"
+// @Router /status [get]
+func GetStatus(c *gin.Context) {
+	appCfg, ok := healthConfig(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, appCfg.Health.Check(c.Request.Context()))
+}
+
+func healthConfig(c *gin.Context) (*AppConfig, bool) {
+	cfg, exists := c.Get("config")
+	if !exists {
+		logger(c).Error("config is missing")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "config not found"})
+		return nil, false
+	}
+	appCfg := cfg.(*AppConfig)
+	if appCfg.Health == nil {
+		logger(c).Error("health checker is missing")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "health checker not found"})
+		return nil, false
+	}
+	return appCfg, true
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/health"
+	"ethereum-validator-api/models"
+)
+
+func TestHealthEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// nothing listens there anymore
+	node := httptest.NewServer(http.NotFoundHandler())
+	node.Close()
+	beacon, err := beaconadapter.NewBeaconClient(node.URL, nil)
+	require.NoError(t, err)
+	execution, err := ethclient.Dial(node.URL)
+	require.NoError(t, err)
+	defer execution.Close()
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: node.URL, Health: health.NewChecker(beacon, execution, 5)}))
+	router.GET("/healthz", GetHealthz)
+	router.GET("/readyz", GetReadyz)
+	router.GET("/status", GetStatus)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, http.NoBody)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/healthz")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = get("/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var readiness models.Readiness
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &readiness))
+	require.False(t, readiness.Ready)
+	require.Equal(t, []string{"beacon node is unreachable", "execution node is unreachable"}, readiness.Reasons)
+
+	w = get("/status")
+	require.Equal(t, http.StatusOK, w.Code)
+	var status models.Status
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	require.Len(t, status.Upstreams, 2)
+	for _, upstream := range status.Upstreams {
+		require.False(t, upstream.Reachable)
+		require.NotEmpty(t, upstream.LastError)
+	}
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"ethereum-validator-api/internal/metrics"
+)
+
+const constUnmatchedRoute = "unmatched"
+
+// MetricsMiddleware records the latency and status of every request by route.
+// Routes are the registered patterns, so the slots in the paths don't blow up the label values.
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" {
+			route = constUnmatchedRoute
+		}
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(MetricsMiddleware())
+	router.GET("/things/:id", func(c *gin.Context) { c.Status(http.StatusTeapot) })
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	for _, path := range []string{"/things/1", "/things/2", "/nothing"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+	}
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `mewatcher_http_request_duration_seconds_count{method="GET",route="/things/:id",status="418"} 2`)
+	require.Contains(t, string(body), `mewatcher_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/models"
+)
+
+// constMaxSlotRange caps range queries to 10 epochs
+const constMaxSlotRange = 320
+
+// @Summary Get missed slots
+// @Description List the slots without a block in the given range, with the validator scheduled to propose each of them
+// @Tags slots
+// @Accept  json
+// @Produce  json
+// @Param   from     query   int     true        "First slot of the range"
+// @Param   to       query   int     true        "Last slot of the range, at most 320 slots after from"
+// @Success 200 {object} models.MissedSlots
+// @Failure 400 {object} models.Error "slot is in the future / invalid request params"
+// @Failure 422 {object} models.Error "the beacon node doesn't serve the proposer duties of the range"
+// @Failure 500 {object} models.Error "internal server error"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /missedslots [get]
+// @Router /v1/missedslots [get]
+func GetMissedSlots(c *gin.Context) {
+	from, err := strconv.ParseInt(c.Query("from"), 10, 64)
+	if err != nil || from < 0 {
+		respondError(c, http.StatusBadRequest, constInvalidSlotNumber)
+		return
+	}
+	to, err := strconv.ParseInt(c.Query("to"), 10, 64)
+	if err != nil || to < from {
+		respondError(c, http.StatusBadRequest, constInvalidSlotNumber)
+		return
+	}
+	if to-from+1 > constMaxSlotRange {
+		respondError(c, http.StatusBadRequest, "Slot range is too wide")
+		return
+	}
+	cfg, exists := c.Get("config")
+	if !exists {
+		logger(c).Error("config is missing")
+		respondError(c, http.StatusInternalServerError, "config not found")
+		return
+	}
+	appCfg := cfg.(*AppConfig)
+	client, err := newBeaconClient(c, appCfg)
+	if err != nil {
+		logger(c).WithError(err).Error("could not init beacon client")
+		respondError(c, http.StatusInternalServerError, "failed to init beacon client")
+		return
+	}
+	if client.MapSlotToTimestamp(to).After(time.Now()) {
+		respondError(c, http.StatusBadRequest, constSlotInFuture)
+		return
+	}
+	missedSlots, err := client.MissedSlots(from, to)
+	if errors.Is(err, beaconadapter.ErrProposersUnavailable) {
+		logger(c).WithError(err).Warnf("no proposer duties for the missed slots in %v-%v", from, to)
+		respondError(c, http.StatusUnprocessableEntity,
+			fmt.Sprintf("the beacon node doesn't serve the proposer duties of slots %v-%v, query an archive node", from, to))
+		return
+	}
+	if err != nil {
+		logger(c).WithError(err).Errorf("could not detect missed slots in %v-%v", from, to)
+		respondError(c, http.StatusInternalServerError, "failed to detect missed slots")
+		return
+	}
+	result := models.MissedSlots{FromSlot: from, ToSlot: to, Slots: make([]models.MissedSlot, 0, len(missedSlots))}
+	for _, missed := range missedSlots {
+		result.Slots = append(result.Slots, models.MissedSlot{
+			Slot:          missed.Slot,
+			Epoch:         missed.Slot / beaconadapter.EthereumSlotsPerEpoch,
+			ProposerIndex: missed.ProposerIndex,
+			Finalized:     missed.Finalized,
+		})
+	}
+	respond(c, http.StatusOK, result, nodeMeta(c))
+}
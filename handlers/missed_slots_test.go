@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/models"
+)
+
+// slotsStandIn is a beacon node with the finalized checkpoint at epoch 4 (slot 128)
+// that has no blocks in the missed slots and fails for the broken ones.
+// It has no proposer duties before epoch 2.
+func slotsStandIn(missed, broken map[int64]bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/finality_checkpoints"):
+			fmt.Fprint(w, `{"data":{"finalized":{"epoch":"4","root":"0x00"}}}`)
+		case strings.Contains(r.URL.Path, "/duties/proposer/"):
+			epoch, _ := strconv.ParseInt(last, 10, 64)
+			if epoch < 2 {
+				// the node only keeps the recent states
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			duties := make([]string, 0, 32)
+			for slot := epoch * 32; slot < (epoch+1)*32; slot++ {
+				duties = append(duties, fmt.Sprintf(`{"pubkey":"0x00","validator_index":"%d","slot":"%d"}`, slot+1000, slot))
+			}
+			fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(duties, ","))
+		case strings.Contains(r.URL.Path, "/headers/"):
+			slot, _ := strconv.ParseInt(last, 10, 64)
+			switch {
+			case broken[slot]:
+				w.WriteHeader(http.StatusServiceUnavailable)
+			case missed[slot]:
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				fmt.Fprintf(w, `{"data":{"root":"0x00","canonical":true,"header":{"message":{"slot":"%d"}}}}`, slot)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGetMissedSlots(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	node := slotsStandIn(map[int64]bool{10: true, 100: true, 130: true, 131: true}, map[int64]bool{200: true})
+	defer node.Close()
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: node.URL}))
+	router.GET("/missedslots", GetMissedSlots)
+
+	t.Run("Missed slots with proposers", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/missedslots?from=90&to=140", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		var result models.MissedSlots
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		require.Equal(t, []models.MissedSlot{
+			{Slot: 100, Epoch: 3, ProposerIndex: 1100, Finalized: true},
+			{Slot: 130, Epoch: 4, ProposerIndex: 1130, Finalized: false},
+			{Slot: 131, Epoch: 4, ProposerIndex: 1131, Finalized: false},
+		}, result.Slots)
+	})
+
+	testCases := []struct {
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{"Node errors are not missed slots (500)", "from=195&to=205", http.StatusInternalServerError},
+		{"No proposer duties (422)", "from=0&to=20", http.StatusUnprocessableEntity},
+		{"No proposer duties needed without missed slots", "from=20&to=40", http.StatusOK},
+		{"Missing bounds (400)", "from=10", http.StatusBadRequest},
+		{"Reversed range (400)", "from=10&to=5", http.StatusBadRequest},
+		{"Range too wide (400)", "from=0&to=1000", http.StatusBadRequest},
+		{"Future slot (400)", "from=4503137824400&to=4503137824401", http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/missedslots?"+tc.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.expectedStatus, w.Code)
+		})
+	}
+}
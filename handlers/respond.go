@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/models"
+)
+
+const constV2Prefix = "/v2/"
+
+// isV2 tells whether the request was routed to the v2 namespace. The v1 namespace
+// and the root aliases answer with the bodies from before the namespaces.
+func isV2(c *gin.Context) bool {
+	return strings.HasPrefix(c.FullPath(), constV2Prefix)
+}
+
+// respond writes the result as it is for v1, in the envelope along with meta for v2.
+func respond(c *gin.Context, code int, data any, meta *models.Meta) {
+	c.JSON(code, versioned(c, data, meta))
+}
+
+// versioned returns the body of a result for the API version of the request.
+func versioned(c *gin.Context, data any, meta *models.Meta) any {
+	if !isV2(c) {
+		return data
+	}
+	if meta == nil {
+		meta = &models.Meta{}
+	}
+	return models.Envelope{Data: data, Meta: meta}
+}
+
+// respondError writes {"error": message} for v1, the error envelope for v2.
+func respondError(c *gin.Context, code int, message string) {
+	if !isV2(c) {
+		c.JSON(code, gin.H{"error": message})
+		return
+	}
+	c.JSON(code, models.ErrorEnvelope{Error: models.ErrorDetail{Status: code, Message: message}, Meta: &models.Meta{}})
+}
+
+// abortWithError is respondError for the middlewares, the handlers after them don't run.
+func abortWithError(c *gin.Context, code int, message string) {
+	respondError(c, code, message)
+	c.Abort()
+}
+
+// nodeMeta is the meta of a result computed from the node data just now.
+func nodeMeta(c *gin.Context) *models.Meta {
+	computedAt := time.Now().UTC()
+	meta := &models.Meta{ComputedAt: &computedAt}
+	if cfg, exists := c.Get("config"); exists {
+		meta.SourceNode = cfg.(*AppConfig).NodeName
+	}
+	return meta
+}
+
+// slotMeta is nodeMeta for a result about a slot.
+func slotMeta(c *gin.Context, slot int64, finalized, executionOptimistic bool) *models.Meta {
+	meta := nodeMeta(c)
+	epoch := slot / beaconadapter.EthereumSlotsPerEpoch
+	meta.Slot = &slot
+	meta.Epoch = &epoch
+	meta.Finalized = &finalized
+	meta.ExecutionOptimistic = &executionOptimistic
+	return meta
+}
+
+// DeprecationMiddleware marks the routes as deprecated in favor of the same path under successorPrefix.
+func DeprecationMiddleware(successorPrefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Link", "<"+successorPrefix+c.Request.URL.Path+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/watch"
+)
+
+func TestVersions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	watchlist, err := watch.NewWatchlist(nil)
+	require.NoError(t, err)
+	require.NoError(t, watchlist.Add(7))
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{NodeName: "node.example", Watchlist: watchlist}))
+	router.GET("/v1/watchlist", GetWatchlist)
+	router.GET("/v1/blockreward/:slot", GetBlockReward)
+	router.GET("/v2/watchlist", GetWatchlistV2)
+	router.GET("/v2/blockreward/:slot", GetBlockRewardV2)
+	deprecated := router.Group("", DeprecationMiddleware("/v1"))
+	deprecated.GET("/watchlist", GetWatchlist)
+	request := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, http.NoBody)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("/v1/watchlist")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"validators":[7]}`, w.Body.String())
+	require.Empty(t, w.Header().Get("Deprecation"))
+	w = request("/v1/blockreward/slot")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.JSONEq(t, `{"error":"`+constInvalidSlotNumber+`"}`, w.Body.String())
+
+	w = request("/v2/watchlist")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"data":{"validators":[7]},"meta":{}}`, w.Body.String())
+	w = request("/v2/blockreward/slot")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.JSONEq(t, `{"error":{"status":400,"message":"`+constInvalidSlotNumber+`"},"meta":{}}`, w.Body.String())
+
+	w = request("/watchlist")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"validators":[7]}`, w.Body.String())
+	require.Equal(t, "true", w.Header().Get("Deprecation"))
+	require.Equal(t, `</v1/watchlist>; rel="successor-version"`, w.Header().Get("Link"))
+}
+
+func TestSlotMeta(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set("config", &AppConfig{NodeName: "node.example"})
+	meta := slotMeta(c, 10560031, true, false)
+	body, err := json.Marshal(meta)
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	require.EqualValues(t, 10560031, decoded["slot"])
+	require.EqualValues(t, 330000, decoded["epoch"])
+	require.Equal(t, true, decoded["finalized"])
+	require.Equal(t, false, decoded["execution_optimistic"])
+	require.Equal(t, "node.example", decoded["source_node"])
+	require.NotEmpty(t, decoded["computed_at"])
+}
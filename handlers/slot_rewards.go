@@ -2,7 +2,8 @@ package handlers
 
 import (
 	"context"
-	"ethereum-validator-api/models"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -10,13 +11,27 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 
+	"ethereum-validator-api/internal/auth"
 	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/metrics"
+	"ethereum-validator-api/internal/prices"
 	"ethereum-validator-api/internal/rewards"
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/models"
 )
 
 const (
 	constSlotInFuture      = "Slot is in the future"
 	constInvalidSlotNumber = "Invalid slot number"
+	constBlockNotFound     = "block not found for slot"
+	constBlockFetchFailed  = "failed to fetch block"
+
+	constCacheHeader = "X-Cache"
+	constCacheHit    = "HIT"
+	constCacheMiss   = "MISS"
+
+	constBlockRewardCache = "blockreward"
+	constSyncDutiesCache  = "syncduties"
 )
 
 // @Summary Get slot reward
@@ -25,76 +40,223 @@ const (
 // @Accept  json
 // @Produce  json
 // @Param   slot     path    int     true        "Slot Number"
+// @Param   mode     query   string  false       "Reward mode, server.mode by default" Enums(light, full)
+// @Param   currency query   string  false       "Fiat currency to value the reward in, e.g. usd, when prices are enabled"
 // @Success 200 {object} models.BlockReward
+// @Header  200 {string} X-Cache "HIT when served from the store of finalized slots, MISS otherwise"
 // @Failure 400 {object} models.Error "slot is in the future / invalid request params"
+// @Failure 403 {object} models.Error "the API key may not request the mode"
 // @Failure 404 {object} models.Error "the slot does not exist / was missed"
 // @Failure 500 {object} models.Error "internal server error"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
 // @Router /blockreward/{slot} [get]
+// @Router /v1/blockreward/{slot} [get]
 func GetBlockReward(c *gin.Context) {
 	// Parse slot parameter
 	slotStr := c.Param("slot")
 	slot, err := strconv.ParseInt(slotStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": constInvalidSlotNumber,
-		})
+		respondError(c, http.StatusBadRequest, constInvalidSlotNumber)
 		return
 	}
 	cfg, exists := c.Get("config")
 	if !exists {
-		logrus.Error("config is missing")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "config not found"})
+		logger(c).Error("config is missing")
+		respondError(c, http.StatusInternalServerError, "config not found")
 		return
 	}
 	appCfg := cfg.(*AppConfig)
-	beaconClient, err := beaconadapter.NewBeaconClient(appCfg.BaseURL, nil)
-	if err != nil {
-		logrus.Error("failed to init the beacon client")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to init beacon client"})
+	mode, ok := allowedMode(c, appCfg)
+	if !ok {
 		return
 	}
-	slotTimestamp := beaconClient.MapSlotToTimestamp(slot)
-	now := time.Now()
-	if slotTimestamp.After(now) {
-		logrus.Errorf("slot %s is in the future", slotTimestamp)
-		c.JSON(http.StatusBadRequest, gin.H{"error": constSlotInFuture})
+	currency, ok := fiatCurrency(c, appCfg)
+	if !ok {
 		return
 	}
-	blockResp, err := beaconClient.FetchBlockResponse(slot)
+	source, err := newRewardSource(c, appCfg, mode)
 	if err != nil {
-		logrus.Errorf("block not found for slot %v", slot)
-		c.JSON(http.StatusNotFound, gin.H{"error": "block not found for slot"})
+		logger(c).WithError(err).Error("failed to init the upstream clients")
+		respondError(c, http.StatusInternalServerError, "failed to init the upstream clients")
 		return
 	}
-	currentBlock, err := strconv.ParseInt(blockResp.Data.Message.Body.ExecutionPayload.BlockNumber, 10, 64)
+	source.currency = currency
+	reward, hit, err := source.reward(c.Request.Context(), slot)
+	if appCfg.Store != nil {
+		if hit {
+			c.Header(constCacheHeader, constCacheHit)
+		} else {
+			c.Header(constCacheHeader, constCacheMiss)
+		}
+	}
 	if err != nil {
-		logrus.Errorf("failed to parse the block number for slot %v", slot)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to parse block number"})
+		code, message := rewardError(err)
+		if code == http.StatusInternalServerError {
+			logger(c).WithError(err).Errorf("failed for slot %v in mode %v", slot, mode)
+		}
+		respondError(c, code, message)
 		return
 	}
+	source.value(c.Request.Context(), reward)
+	respond(c, http.StatusOK, blockReward(reward), rewardMeta(c, reward))
+}
+
+var (
+	errSlotInFuture = errors.New(constSlotInFuture)
+	errBlockFetch   = errors.New(constBlockFetchFailed)
+)
+
+// rewardSource looks the rewards of a request up in the store or computes them.
+type rewardSource struct {
+	store   *store.Store
+	beacon  *beaconadapter.BeaconClient
+	rewards *rewards.RewardsClient
+	mode    string
+	// prices values the rewards in currency, when one is requested
+	prices   prices.Source
+	currency string
+}
+
+func newRewardSource(c *gin.Context, appCfg *AppConfig, mode string) (*rewardSource, error) {
+	beaconClient, err := newBeaconClient(c, appCfg)
+	if err != nil {
+		return nil, err
+	}
 	rewardsClient, err := rewards.NewRewardsClient(appCfg.BaseURL, appCfg.EthScanAPIKey)
 	if err != nil {
-		logrus.Error("failed to init the reward client")
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Could init the reward client",
-		})
-		return
+		return nil, err
+	}
+	return &rewardSource{store: appCfg.Store, beacon: beaconClient, rewards: rewardsClient, mode: mode, prices: appCfg.Prices}, nil
+}
+
+// reward returns the reward of the slot and whether it was stored. The finalized rewards it computes are stored.
+func (s *rewardSource) reward(ctx context.Context, slot int64) (*models.RewardBreakdown, bool, error) {
+	if cached := s.stored(ctx, slot); cached != nil {
+		return cached, true, nil
+	}
+	if s.beacon.MapSlotToTimestamp(slot).After(time.Now()) {
+		return nil, false, errSlotInFuture
+	}
+	if slot < 0 {
+		// there are no blocks before genesis
+		return nil, false, beaconadapter.ErrNotFound
+	}
+	blockResp, err := s.beacon.FetchBlockResponse(slot)
+	if errors.Is(err, beaconadapter.ErrNotFound) {
+		return nil, false, err
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %w", errBlockFetch, err)
+	}
+	reward, err := s.compute(ctx, blockResp)
+	return reward, false, err
+}
+
+// stored returns the stored reward of the slot, nil unless it is finalized.
+func (s *rewardSource) stored(ctx context.Context, slot int64) *models.RewardBreakdown {
+	if s.store == nil {
+		return nil
+	}
+	cached, err := s.store.GetBlockReward(slot, s.mode)
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).Warnf("failed to read the stored reward for slot %v", slot)
+	}
+	// the head follower also stores rewards before finality, those may still change
+	hit := cached != nil && cached.Finalized
+	metrics.ObserveCache(constBlockRewardCache, hit)
+	if !hit {
+		return nil
+	}
+	return cached
+}
+
+// compute computes the reward of a fetched block and stores it once finalized.
+func (s *rewardSource) compute(ctx context.Context, blockResp *beaconadapter.BlockResponse) (*models.RewardBreakdown, error) {
+	logrus.WithContext(ctx).Infof("operating in %v mode for slot %v", s.mode, blockResp.Data.Message.Slot)
+	reward, err := s.rewards.GetBlockRewardBreakdown(ctx, blockResp, s.mode)
+	if err != nil {
+		return nil, err
+	}
+	if s.store != nil && reward.Finalized {
+		if err := s.store.PutBlockReward(reward); err != nil {
+			logrus.WithContext(ctx).WithError(err).Warnf("failed to store the reward for slot %v", reward.Slot)
+		}
 	}
-	var reward *models.BlockReward
-	if appCfg.Mode == "beast" {
-		logrus.Infof("operating in beast mode for slot %v", slot)
-		reward, err = rewardsClient.GetBlockRewardFull(context.Background(), slot)
-	} else {
-		logrus.Infof("operating in light mode for slot %v", slot)
-		reward, err = rewardsClient.GetBlockRewardLight(context.Background(), currentBlock)
+	return reward, nil
+}
+
+// value sets the fiat value of the reward when a currency is requested. Without a price for the day
+// of the block the reward is left without it.
+func (s *rewardSource) value(ctx context.Context, reward *models.RewardBreakdown) {
+	if s.currency == "" {
+		return
 	}
+	fiat, err := prices.Value(ctx, s.prices, s.currency, s.beacon.MapSlotToTimestamp(reward.Slot), reward.Reward)
 	if err != nil {
-		logrus.WithError(err).Errorf("failed for slot %v in mode %v", slot, appCfg.Mode)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Internal server error",
-		})
+		logrus.WithContext(ctx).WithError(err).Warnf("failed to value the reward of slot %v", reward.Slot)
 		return
 	}
+	reward.Fiat = fiat
+}
+
+// rewardError is the status and the message answered for an error of rewardSource.reward.
+func rewardError(err error) (int, string) {
+	switch {
+	case errors.Is(err, errSlotInFuture):
+		return http.StatusBadRequest, constSlotInFuture
+	case errors.Is(err, beaconadapter.ErrNotFound):
+		return http.StatusNotFound, constBlockNotFound
+	case errors.Is(err, errBlockFetch):
+		return http.StatusInternalServerError, constBlockFetchFailed
+	}
+	return http.StatusInternalServerError, "Internal server error"
+}
+
+// requestMode is the mode of the mode query param, the configured one by default.
+func requestMode(c *gin.Context, appCfg *AppConfig) (string, error) {
+	requested, ok := c.GetQuery("mode")
+	if !ok {
+		return rewards.NormalizeMode(appCfg.Mode), nil
+	}
+	return rewards.ParseMode(requested)
+}
+
+// allowedMode is requestMode checked against the API key. It answers the request when the mode can't be used.
+func allowedMode(c *gin.Context, appCfg *AppConfig) (string, bool) {
+	mode, err := requestMode(c, appCfg)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return "", false
+	}
+	if key, exists := c.Get(constAPIKeyContext); exists && !key.(*auth.Key).AllowsMode(mode) {
+		respondError(c, http.StatusForbidden, "the API key may not request the "+rewards.APIMode(mode)+" mode")
+		return "", false
+	}
+	return mode, true
+}
+
+// fiatCurrency is the currency of the currency query param, "" when none is requested.
+// It answers the request when the rewards can't be valued.
+func fiatCurrency(c *gin.Context, appCfg *AppConfig) (string, bool) {
+	currency := prices.NormalizeCurrency(c.Query("currency"))
+	if currency != "" && appCfg.Prices == nil {
+		respondError(c, http.StatusBadRequest, "fiat valuation is disabled")
+		return "", false
+	}
+	return currency, true
+}
+
+// blockReward is the answer of /blockreward, with the mode as the API names it.
+func blockReward(reward *models.RewardBreakdown) *models.BlockReward {
+	result := reward.BlockReward()
+	result.Mode = rewards.APIMode(reward.Mode)
+	return result
+}
 
-	c.JSON(http.StatusOK, reward)
+// rewardMeta is the v2 meta of a computed or stored reward.
+func rewardMeta(c *gin.Context, reward *models.RewardBreakdown) *models.Meta {
+	meta := slotMeta(c, reward.Slot, reward.Finalized, reward.ExecutionOptimistic)
+	meta.ComputedAt = reward.ComputedAt
+	return meta
 }
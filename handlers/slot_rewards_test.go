@@ -6,12 +6,19 @@ import (
 	"github.com/spf13/viper"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/auth"
+	"ethereum-validator-api/internal/prices"
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/models"
 )
 
 func loadConfig() (string, error) {
@@ -91,3 +98,115 @@ func TestGetSlotRewardLight(t *testing.T) {
 	}
 
 }
+
+func TestGetSlotRewardFromStore(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+	require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{
+		Slot: 4700013, Mode: "light", Reward: 45031378244, Finalized: true,
+	}))
+
+	// the node is unreachable, so only the store can answer
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: "http://127.0.0.1:0", Mode: "light", Store: s}))
+	router.GET("/blockreward/:slot", GetBlockReward)
+
+	req, _ := http.NewRequest("GET", "/blockreward/4700013", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, constCacheHit, w.Header().Get(constCacheHeader))
+	assert.JSONEq(t, `{"status":false,"reward":45031378244,"execution_optimistic":false,"finalized":true,"mode":"light"}`, w.Body.String())
+
+	// the beast mode result for the same slot is a different record
+	router = gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: "http://127.0.0.1:0", Mode: "beast", Store: s}))
+	router.GET("/blockreward/:slot", GetBlockReward)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, constCacheMiss, w.Header().Get(constCacheHeader))
+	assert.NotEqual(t, http.StatusOK, w.Code)
+}
+
+func TestGetSlotRewardMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+	require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: 4700013, Mode: "light", Reward: 1, Finalized: true}))
+	require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: 4700013, Mode: "beast", Reward: 2, Finalized: true}))
+	keyring, err := auth.NewKeyring([]auth.Key{
+		{Name: "light", Hash: auth.HashKey("light"), Modes: []string{"light"}},
+		{Name: "any", Hash: auth.HashKey("any")},
+	}, "")
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: "http://127.0.0.1:0", Mode: "light", Store: s}))
+	router.Use(AuthMiddleware(keyring, auth.NewLimiter(auth.Config{})))
+	router.GET("/blockreward/:slot", GetBlockReward)
+	request := func(query, key string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/blockreward/4700013"+query, nil)
+		req.Header.Set(constAPIKeyHeader, key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("", "any")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"mode":"light"`)
+	w = request("?mode=full", "any")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"reward":2`)
+	assert.Contains(t, w.Body.String(), `"mode":"full"`)
+	w = request("?mode=turbo", "any")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = request("?mode=light", "light")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = request("?mode=full", "light")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGetSlotRewardFiat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+	require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: 4700013, Mode: "light", Reward: 45031378244, Finalized: true}))
+	// slot 4700013 is on 2022-09-15
+	priceSource, err := prices.ReadCSV(strings.NewReader("date,usd\n2022-09-15,1600\n"))
+	require.NoError(t, err)
+	request := func(appCfg *AppConfig, query string) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.Use(ConfigMiddleware(appCfg))
+		router.GET("/blockreward/:slot", GetBlockReward)
+		req, _ := http.NewRequest("GET", "/blockreward/4700013"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	appCfg := &AppConfig{BaseURL: "http://127.0.0.1:0", Mode: "light", Store: s, Prices: priceSource}
+
+	w := request(appCfg, "?currency=USD")
+	require.Equal(t, http.StatusOK, w.Code)
+	var reward models.BlockReward
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &reward))
+	require.NotNil(t, reward.Fiat)
+	assert.Equal(t, "usd", reward.Fiat.Currency)
+	assert.Equal(t, "2022-09-15", reward.Fiat.Date)
+	assert.InDelta(t, 45.031378244*1600, reward.Fiat.Reward, 1e-6)
+	// the reward is served without a price too, and without a currency
+	w = request(appCfg, "?currency=eur")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "fiat")
+	w = request(appCfg, "")
+	assert.NotContains(t, w.Body.String(), "fiat")
+
+	appCfg.Prices = nil
+	w = request(appCfg, "?currency=usd")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"ethereum-validator-api/internal/rewards"
+	"ethereum-validator-api/models"
+)
+
+const (
+	constBlockRewardEvent = "blockreward"
+	// constStreamBuffer is how far a client may fall behind before it gets disconnected
+	constStreamBuffer = 64
+	// constMaxResumeSlots caps the replay from the store on reconnect to about 3.5 hours
+	constMaxResumeSlots    = 1024
+	constKeepAliveInterval = 15 * time.Second
+	constWriteTimeout      = 10 * time.Second
+	constStreamDisabled    = "streaming requires the head follower"
+)
+
+// the dashboards are served from other origins
+var upgrader = websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+
+// rewardFilter keeps the rewards matching all the set filters.
+type rewardFilter struct {
+	proposerIndex *int64
+	feeRecipient  string
+	mev           *bool
+}
+
+func parseRewardFilter(c *gin.Context) (*rewardFilter, error) {
+	filter := &rewardFilter{feeRecipient: strings.ToLower(c.Query("fee_recipient"))}
+	if proposerStr := c.Query("proposer_index"); proposerStr != "" {
+		proposerIndex, err := strconv.ParseInt(proposerStr, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proposer index: %w", err)
+		}
+		filter.proposerIndex = &proposerIndex
+	}
+	if mevStr := c.Query("mev"); mevStr != "" {
+		mev, err := strconv.ParseBool(mevStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mev flag: %w", err)
+		}
+		filter.mev = &mev
+	}
+	return filter, nil
+}
+
+func (f *rewardFilter) match(reward *models.RewardBreakdown) bool {
+	if f.proposerIndex != nil && *f.proposerIndex != reward.ProposerIndex {
+		return false
+	}
+	if f.feeRecipient != "" && f.feeRecipient != strings.ToLower(reward.FeeRecipient) {
+		return false
+	}
+	return f.mev == nil || *f.mev == reward.Mev
+}
+
+// rewardStream is a subscription to the new rewards along with the stored
+// ones the client missed since its last event.
+type rewardStream struct {
+	filter  *rewardFilter
+	replay  []*models.RewardBreakdown
+	rewards <-chan *models.RewardBreakdown
+	cancel  func()
+	// shuttingDown tells, once rewards is closed, whether it's for the server shutdown rather than a slow client
+	shuttingDown func() bool
+}
+
+// openRewardStream subscribes to the new rewards and loads the ones after lastEventID from the store.
+// It writes the error response itself and returns false when the stream can't be opened.
+func openRewardStream(c *gin.Context, lastEventID string) (*rewardStream, bool) {
+	filter, err := parseRewardFilter(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	lastSlot := int64(-1)
+	if lastEventID != "" {
+		lastSlot, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid last event id")
+			return nil, false
+		}
+	}
+	cfg, exists := c.Get("config")
+	if !exists {
+		logger(c).Error("config is missing")
+		respondError(c, http.StatusInternalServerError, "config not found")
+		return nil, false
+	}
+	appCfg := cfg.(*AppConfig)
+	if appCfg.Stream == nil {
+		respondError(c, http.StatusServiceUnavailable, constStreamDisabled)
+		return nil, false
+	}
+	// subscribe first, so nothing falls in between the replay and the live rewards
+	ch, cancel := appCfg.Stream.Subscribe(constStreamBuffer)
+	stream := &rewardStream{filter: filter, rewards: ch, cancel: cancel, shuttingDown: appCfg.Stream.Closed}
+	if lastSlot >= 0 && appCfg.Store != nil {
+		stream.replay, err = appCfg.Store.BlockRewards(rewards.NormalizeMode(appCfg.Mode), lastSlot+1, lastSlot+constMaxResumeSlots)
+		if err != nil {
+			cancel()
+			logger(c).WithError(err).Errorf("failed to read the stored rewards after slot %v", lastSlot)
+			respondError(c, http.StatusInternalServerError, "failed to resume the stream")
+			return nil, false
+		}
+	}
+	return stream, true
+}
+
+// @Summary Stream block rewards
+// @Description Push the reward breakdown of every new head block as server-sent events, as soon as it is computed.
+// @Description The event id is the slot; on reconnect the rewards stored after the Last-Event-ID slot are replayed first.
+// @Description A slot is sent again when a reorg changes its block.
+// @Tags rewards
+// @Produce  text/event-stream
+// @Param   proposer_index  query   int     false       "Only blocks of this proposer"
+// @Param   fee_recipient   query   string  false       "Only blocks paying this fee recipient"
+// @Param   mev             query   bool    false       "Only MEV / non-MEV blocks"
+// @Param   Last-Event-ID   header  int     false       "Slot of the last event received"
+// @Success 200 {object} models.RewardBreakdown
+// @Failure 400 {object} models.Error "invalid request params"
+// @Failure 503 {object} models.Error "the head follower is disabled"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /stream/blockrewards [get]
+// @Router /v1/stream/blockrewards [get]
+func StreamBlockRewards(c *gin.Context) {
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	stream, ok := openRewardStream(c, lastEventID)
+	if !ok {
+		return
+	}
+	defer stream.cancel()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// keeps reverse proxies from buffering the events
+	c.Header("X-Accel-Buffering", "no")
+	// the server write timeout is meant for regular requests, not for a stream
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		logger(c).WithError(err).Debug("failed to clear the write deadline of the stream")
+	}
+	c.Status(http.StatusOK)
+	send := func(reward *models.RewardBreakdown) error {
+		if !stream.filter.match(reward) {
+			return nil
+		}
+		data, err := json.Marshal(versioned(c, reward, rewardMeta(c, reward)))
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", reward.Slot, constBlockRewardEvent, data)
+		return err
+	}
+	for _, reward := range stream.replay {
+		if err := send(reward); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(constKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case reward, ok := <-stream.rewards:
+			if !ok {
+				// too slow or the server is shutting down, the client resumes from the store after reconnecting
+				return
+			}
+			if err := send(reward); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// @Summary Stream block rewards over WebSocket
+// @Description The WebSocket variant of /stream/blockrewards: every message is a reward breakdown as JSON.
+// @Description Pass the slot of the last message received as last_event_id to resume after reconnecting.
+// @Tags rewards
+// @Param   proposer_index  query   int     false       "Only blocks of this proposer"
+// @Param   fee_recipient   query   string  false       "Only blocks paying this fee recipient"
+// @Param   mev             query   bool    false       "Only MEV / non-MEV blocks"
+// @Param   last_event_id   query   int     false       "Slot of the last message received"
+// @Success 101 {object} models.RewardBreakdown
+// @Failure 400 {object} models.Error "invalid request params"
+// @Failure 503 {object} models.Error "the head follower is disabled"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /stream/blockrewards/ws [get]
+// @Router /v1/stream/blockrewards/ws [get]
+func StreamBlockRewardsWS(c *gin.Context) {
+	stream, ok := openRewardStream(c, c.Query("last_event_id"))
+	if !ok {
+		return
+	}
+	defer stream.cancel()
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the upgrader has already responded
+		logger(c).WithError(err).Warn("failed to upgrade to websocket")
+		return
+	}
+	defer conn.Close()
+
+	// the client never sends anything, reading only notices when it goes away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	send := func(reward *models.RewardBreakdown) error {
+		if !stream.filter.match(reward) {
+			return nil
+		}
+		conn.SetWriteDeadline(time.Now().Add(constWriteTimeout))
+		return conn.WriteJSON(versioned(c, reward, rewardMeta(c, reward)))
+	}
+	for _, reward := range stream.replay {
+		if err := send(reward); err != nil {
+			return
+		}
+	}
+
+	keepAlive := time.NewTicker(constKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case reward, ok := <-stream.rewards:
+			if !ok {
+				message := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too slow")
+				if stream.shuttingDown() {
+					message = websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+				}
+				conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(constWriteTimeout))
+				return
+			}
+			if err := send(reward); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(constWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
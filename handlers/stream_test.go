@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/internal/stream"
+	"ethereum-validator-api/models"
+)
+
+func streamTestServer(t *testing.T) (*httptest.Server, *stream.Hub) {
+	gin.SetMode(gin.TestMode)
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	for slot := int64(100); slot <= 102; slot++ {
+		require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: slot, Mode: "light", Mev: slot == 101}))
+	}
+	hub := stream.NewHub()
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{Mode: "light", Store: s, Stream: hub}))
+	router.GET("/stream/blockrewards", StreamBlockRewards)
+	router.GET("/stream/blockrewards/ws", StreamBlockRewardsWS)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server, hub
+}
+
+func TestStreamBlockRewards(t *testing.T) {
+	server, hub := streamTestServer(t)
+	req, err := http.NewRequest("GET", server.URL+"/stream/blockrewards?mev=false", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "100")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() (id string, reward models.RewardBreakdown) {
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case line == "":
+				return id, reward
+			case strings.HasPrefix(line, "id: "):
+				id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &reward))
+			}
+		}
+	}
+	// 101 is filtered out of the replay
+	id, reward := readEvent()
+	require.Equal(t, "102", id)
+	require.Equal(t, int64(102), reward.Slot)
+
+	hub.Publish(&models.RewardBreakdown{Slot: 103, Mode: "light", Mev: true})
+	hub.Publish(&models.RewardBreakdown{Slot: 104, Mode: "light", ProposerIndex: 7})
+	id, reward = readEvent()
+	require.Equal(t, "104", id)
+	require.Equal(t, int64(7), reward.ProposerIndex)
+}
+
+func TestStreamBlockRewardsWS(t *testing.T) {
+	server, hub := streamTestServer(t)
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/stream/blockrewards/ws?proposer_index=7&last_event_id=99"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	hub.Publish(&models.RewardBreakdown{Slot: 103, ProposerIndex: 8})
+	hub.Publish(&models.RewardBreakdown{Slot: 104, ProposerIndex: 7})
+	var reward models.RewardBreakdown
+	require.NoError(t, conn.ReadJSON(&reward))
+	require.Equal(t, int64(104), reward.Slot)
+}
+
+func TestStreamBlockRewardsErrors(t *testing.T) {
+	server, _ := streamTestServer(t)
+	resp, err := http.Get(server.URL + "/stream/blockrewards?mev=maybe")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{Mode: "light"}))
+	router.GET("/stream/blockrewards", StreamBlockRewards)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/stream/blockrewards", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
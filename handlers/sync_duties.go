@@ -1,90 +1,187 @@
 package handlers
 
 import (
-	"github.com/sirupsen/logrus"
+	"errors"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/metrics"
+	"ethereum-validator-api/internal/rewards"
 	"ethereum-validator-api/models"
 )
 
 // @Summary Get sync duties for given slot
-// @Description Get the pubkeys of the validators in the sync committee for a specific slot
+// @Description Get the pubkeys of the validators in the sync committee for a specific slot.
+// @Description The validators are listed once each, by index. With detail=true, full validator records (models.SyncDutiesDetail)
+// @Description are returned in committee order instead, a validator once per seat.
 // @Tags syncduties
 // @Accept  json
 // @Produce  json
 // @Param   slot     path    int     true        "Slot Number"
+// @Param   detail   query   bool    false       "Return full validator records"
 // @Success 200 {object} models.SyncDuties
+// @Header  200 {string} X-Cache "HIT when served from the store of finalized slots, MISS otherwise"
 // @Failure 400 {object} models.Error "slot is in the future / invalid request params"
 // @Failure 404 {object} models.Error "the slot does not exist / was missed"
 // @Failure 500 {object} models.Error "internal server error"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
 // @Router /syncduties/{slot} [get]
+// @Router /v1/syncduties/{slot} [get]
 func GetSyncDuties(c *gin.Context) {
 	// Parse slot parameter
 	slotStr := c.Param("slot")
 	slot, err := strconv.ParseInt(slotStr, 10, 64)
 	if err != nil {
-		logrus.WithError(err).Error("error parsing slot")
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid slot number",
-		})
+		logger(c).WithError(err).Error("error parsing slot")
+		respondError(c, http.StatusBadRequest, "Invalid slot number")
 		return
 	}
+	detail := false
+	if detailStr := c.Query("detail"); detailStr != "" {
+		detail, err = strconv.ParseBool(detailStr)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid detail flag")
+			return
+		}
+	}
 	cfg, exists := c.Get("config")
 	if !exists {
-		logrus.WithError(err).Error("problem with config")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "config not found"})
+		logger(c).WithError(err).Error("problem with config")
+		respondError(c, http.StatusInternalServerError, "config not found")
 		return
 	}
 	appCfg := cfg.(*AppConfig)
-	client, err := beaconadapter.NewBeaconClient(appCfg.BaseURL, nil)
+	if appCfg.Store != nil {
+		cached, err := appCfg.Store.GetSyncCommittee(slot)
+		if err != nil {
+			logger(c).WithError(err).Warnf("failed to read the stored sync committee for slot %v", slot)
+		}
+		metrics.ObserveCache(constSyncDutiesCache, cached != nil)
+		if cached != nil {
+			// only finalized committees are ever stored
+			cached.Finalized = true
+			c.Header(constCacheHeader, constCacheHit)
+			meta := slotMeta(c, slot, true, cached.ExecutionOptimistic)
+			// when it was computed isn't stored
+			meta.ComputedAt = nil
+			respondSyncDuties(c, cached, detail, meta)
+			return
+		}
+		c.Header(constCacheHeader, constCacheMiss)
+	}
+	client, err := newBeaconClient(c, appCfg)
 	if err != nil {
-		logrus.WithError(err).Error("could not init beacon client")
-		c.JSON(http.StatusInternalServerError, err.Error())
+		logger(c).WithError(err).Error("could not init beacon client")
+		respondError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 	slotTimestamp := client.MapSlotToTimestamp(slot)
 	now := time.Now()
 	if slotTimestamp.After(now) {
-		logrus.WithError(err).Errorf("slot %v is in the future", slot)
-		c.JSON(http.StatusBadRequest, gin.H{"error": constSlotInFuture})
+		logger(c).WithError(err).Errorf("slot %v is in the future", slot)
+		respondError(c, http.StatusBadRequest, constSlotInFuture)
+		return
+	}
+	if slot < 0 {
+		// there are no blocks before genesis
+		respondError(c, http.StatusNotFound, constBlockNotFound)
 		return
 	}
 	_, err = client.FetchBlockResponse(slot)
+	if errors.Is(err, beaconadapter.ErrNotFound) {
+		logger(c).Errorf("block not found for slot %v", slot)
+		respondError(c, http.StatusNotFound, constBlockNotFound)
+		return
+	}
 	if err != nil {
-		logrus.Errorf("block not found for slot %v", slot)
-		c.JSON(http.StatusNotFound, gin.H{"error": "block not found for slot"})
+		logger(c).WithError(err).Errorf("could not fetch block for slot %v", slot)
+		respondError(c, http.StatusInternalServerError, constBlockFetchFailed)
 		return
 	}
 	dutiesResp, err := client.FetchSyncDuties(slot)
 	if err != nil {
-		logrus.WithError(err).Errorf("could not fetch synduties for slot %v", slot)
-		c.JSON(http.StatusInternalServerError, err.Error())
+		logger(c).WithError(err).Errorf("could not fetch synduties for slot %v", slot)
+		respondError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
-	result := models.SyncDuties{Validators: []string{}}
-	indices := make([]int64, 0)
+	indices := make([]int64, 0, len(dutiesResp.Data.Validators))
 	for _, item := range dutiesResp.Data.Validators {
 		index, err := strconv.ParseInt(item, 10, 64)
 		if err != nil {
-			logrus.WithError(err).Errorf("could not convert valkeys for slot %v", slot)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "index conversion failed"})
+			logger(c).WithError(err).Errorf("could not convert valkeys for slot %v", slot)
+			respondError(c, http.StatusInternalServerError, "index conversion failed")
 			return
 		}
 		indices = append(indices, index)
 	}
+	if !detail && appCfg.Registry != nil {
+		if pubkeys, ok := appCfg.Registry.Pubkeys(uniqueSortedIndices(indices)); ok {
+			respond(c, http.StatusOK, models.SyncDuties{
+				Validators:          pubkeys,
+				ExecutionOptimistic: dutiesResp.ExecutionOptimistic,
+				Finalized:           dutiesResp.Finalized,
+			}, slotMeta(c, slot, dutiesResp.Finalized, dutiesResp.ExecutionOptimistic))
+			return
+		}
+	}
 	validatorResp, err := client.PublicKeysByValidatorIDs(indices, slot)
 	if err != nil {
+		logger(c).WithError(err).Errorf("could not fetch validators for slot %v", slot)
+		respondError(c, http.StatusInternalServerError, "failed to fetch validators")
+		return
+	}
+	members, err := rewards.SyncCommitteeMembers(indices, len(dutiesResp.Data.ValidatorAggregates), validatorResp.Data)
+	if err != nil {
+		logger(c).WithError(err).Errorf("could not map validators for slot %v", slot)
+		respondError(c, http.StatusInternalServerError, "validator conversion failed")
 		return
 	}
+	duties := &models.SyncDutiesDetail{
+		Validators:          members,
+		ExecutionOptimistic: dutiesResp.ExecutionOptimistic || validatorResp.ExecutionOptimistic,
+		Finalized:           dutiesResp.Finalized,
+	}
+	if appCfg.Store != nil && duties.Finalized {
+		if err := appCfg.Store.PutSyncCommittee(slot, duties); err != nil {
+			logger(c).WithError(err).Warnf("failed to store the sync committee for slot %v", slot)
+		}
+	}
+	respondSyncDuties(c, duties, detail, slotMeta(c, slot, duties.Finalized, duties.ExecutionOptimistic))
+}
 
-	//nolint:gocritic // That's expected
-	for _, validator := range validatorResp.Data {
-		result.Validators = append(result.Validators, validator.Validator.Pubkey)
+func respondSyncDuties(c *gin.Context, duties *models.SyncDutiesDetail, detail bool, meta *models.Meta) {
+	if detail {
+		respond(c, http.StatusOK, duties, meta)
+		return
+	}
+	// the default answer lists every validator once by index, as the beacon node does
+	pubkeys := make(map[int64]string, len(duties.Validators))
+	indices := make([]int64, 0, len(duties.Validators))
+	for _, member := range duties.Validators {
+		pubkeys[member.Index] = member.Pubkey
+		indices = append(indices, member.Index)
+	}
+	indices = uniqueSortedIndices(indices)
+	result := models.SyncDuties{
+		Validators:          make([]string, 0, len(indices)),
+		ExecutionOptimistic: duties.ExecutionOptimistic,
+		Finalized:           duties.Finalized,
 	}
-	c.JSON(http.StatusOK, result)
+	for _, index := range indices {
+		result.Validators = append(result.Validators, pubkeys[index])
+	}
+	respond(c, http.StatusOK, result, meta)
+}
+
+// uniqueSortedIndices returns the validator indices sorted, each once.
+func uniqueSortedIndices(indices []int64) []int64 {
+	sorted := slices.Clone(indices)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
 }
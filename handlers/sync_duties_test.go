@@ -6,10 +6,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/models"
 )
 
 func TestSyncDuties(t *testing.T) {
@@ -72,3 +75,48 @@ func TestSyncDuties(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncDutiesOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// validator 9 holds two seats of the committee, the node answers every validator once by index
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/beacon/blocks/"):
+			fmt.Fprint(w, `{"version":"deneb","finalized":true,"data":{"message":{"slot":"100","body":{}}}}`)
+		case strings.HasSuffix(r.URL.Path, "/sync_committees"):
+			fmt.Fprint(w, `{"finalized":true,"data":{"validators":["9","3","9","5"],"validator_aggregates":[["9","3"],["9","5"]]}}`)
+		case strings.HasSuffix(r.URL.Path, "/validators"):
+			fmt.Fprint(w, `{"finalized":true,"data":[`+
+				`{"index":"3","balance":"32000000000","status":"active_ongoing","validator":{"pubkey":"0x03","effective_balance":"32000000000"}},`+
+				`{"index":"5","balance":"32000000000","status":"active_ongoing","validator":{"pubkey":"0x05","effective_balance":"32000000000"}},`+
+				`{"index":"9","balance":"32000000000","status":"active_ongoing","validator":{"pubkey":"0x09","effective_balance":"32000000000"}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer node.Close()
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: node.URL}))
+	router.GET("/syncduties/:slot", GetSyncDuties)
+
+	req, _ := http.NewRequest("GET", "/syncduties/100", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var duties models.SyncDuties
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &duties))
+	require.Equal(t, []string{"0x03", "0x05", "0x09"}, duties.Validators)
+
+	req, _ = http.NewRequest("GET", "/syncduties/100?detail=true", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var detail models.SyncDutiesDetail
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+	pubkeys := make([]string, 0, len(detail.Validators))
+	for _, member := range detail.Validators {
+		pubkeys = append(pubkeys, member.Pubkey)
+	}
+	require.Equal(t, []string{"0x09", "0x03", "0x09", "0x05"}, pubkeys)
+	require.Equal(t, 1, detail.Validators[2].SubcommitteeIndex)
+}
@@ -0,0 +1,275 @@
+package handlers
+
+import "github.com/gin-gonic/gin"
+
+// The v2 routes run the v1 handlers, which answer in the envelope when routed under /v2.
+// They are declared apart for the API docs only.
+
+// @Summary Get slot reward
+// @Description Get the reward for a specific slot, in the v2 envelope
+// @Tags v2
+// @Produce  json
+// @Param   slot     path    int     true        "Slot Number"
+// @Param   mode     query   string  false       "Reward mode, server.mode by default" Enums(light, full)
+// @Param   currency query   string  false       "Fiat currency to value the reward in, e.g. usd, when prices are enabled"
+// @Success 200 {object} models.Envelope{data=models.BlockReward}
+// @Header  200 {string} X-Cache "HIT when served from the store of finalized slots, MISS otherwise"
+// @Failure 400 {object} models.ErrorEnvelope "slot is in the future / invalid request params"
+// @Failure 403 {object} models.ErrorEnvelope "the API key may not request the mode"
+// @Failure 404 {object} models.ErrorEnvelope "the slot does not exist / was missed"
+// @Failure 500 {object} models.ErrorEnvelope "internal server error"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/blockreward/{slot} [get]
+func GetBlockRewardV2(c *gin.Context) {
+	GetBlockReward(c)
+}
+
+// @Summary Get the rewards of a slot range
+// @Description Get the rewards of the slots in [from_slot, to_slot], a page of at most limit slots at a time, in the v2 envelope.
+// @Description The slots that fail, the missed ones included, carry their error instead of failing the page.
+// @Tags v2
+// @Produce  json,text/csv,application/x-ndjson
+// @Param   from_slot query   int     true        "First slot of the range"
+// @Param   to_slot   query   int     true        "Last slot of the range"
+// @Param   limit     query   int     false       "Slots per page, 32 by default, at most 100"
+// @Param   mode      query   string  false       "Reward mode, server.mode by default" Enums(light, full)
+// @Param   currency  query   string  false       "Fiat currency to value the reward in, e.g. usd, when prices are enabled"
+// @Param   format    query   string  false       "Answer format, negotiated from the Accept header by default" Enums(json, csv, ndjson)
+// @Success 200 {object} models.Envelope{data=models.BlockRewards}
+// @Failure 400 {object} models.ErrorEnvelope "slot is in the future / invalid request params"
+// @Failure 403 {object} models.ErrorEnvelope "the API key may not request the mode"
+// @Failure 500 {object} models.ErrorEnvelope "internal server error"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/blockrewards [get]
+func GetBlockRewardsV2(c *gin.Context) {
+	GetBlockRewards(c)
+}
+
+// @Summary Get the rewards of a list of slots
+// @Description Get the rewards of up to 100 slots, in the order they are listed, without the duplicates, in the v2 envelope.
+// @Description The slots that fail, the missed and the future ones included, carry their error instead of failing the batch.
+// @Tags v2
+// @Accept  json
+// @Produce  json,text/csv,application/x-ndjson
+// @Param   request  body    models.BlockRewardsRequest  true  "Slots"
+// @Param   mode     query   string  false       "Reward mode, server.mode by default" Enums(light, full)
+// @Param   currency query   string  false       "Fiat currency to value the reward in, e.g. usd, when prices are enabled"
+// @Param   format   query   string  false       "Answer format, negotiated from the Accept header by default" Enums(json, csv, ndjson)
+// @Success 200 {object} models.Envelope{data=models.BlockRewards}
+// @Failure 400 {object} models.ErrorEnvelope "invalid request params"
+// @Failure 403 {object} models.ErrorEnvelope "the API key may not request the mode"
+// @Failure 500 {object} models.ErrorEnvelope "internal server error"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/blockrewards [post]
+func PostBlockRewardsV2(c *gin.Context) {
+	PostBlockRewards(c)
+}
+
+// @Summary Get sync duties for given slot
+// @Description Get the pubkeys of the validators in the sync committee for a specific slot, in the v2 envelope.
+// @Description With detail=true, full validator records (models.SyncDutiesDetail) are returned in committee order instead.
+// @Tags v2
+// @Produce  json
+// @Param   slot     path    int     true        "Slot Number"
+// @Param   detail   query   bool    false       "Return full validator records"
+// @Success 200 {object} models.Envelope{data=models.SyncDuties}
+// @Header  200 {string} X-Cache "HIT when served from the store of finalized slots, MISS otherwise"
+// @Failure 400 {object} models.ErrorEnvelope "slot is in the future / invalid request params"
+// @Failure 404 {object} models.ErrorEnvelope "the slot does not exist / was missed"
+// @Failure 500 {object} models.ErrorEnvelope "internal server error"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/syncduties/{slot} [get]
+func GetSyncDutiesV2(c *gin.Context) {
+	GetSyncDuties(c)
+}
+
+// @Summary Get epoch summary
+// @Description Sum up the epoch, in the v2 envelope: proposed and missed slots, EL rewards of the proposers (fees, burns, MEV payments),
+// @Description CL rewards of the proposers, MEV-boost share, attestation participation, finality and sync committee period.
+// @Description The participation counts the attestations included up to the end of the next epoch, it is provisional until then.
+// @Tags v2
+// @Produce  json
+// @Param   epoch    path    int     true        "Epoch Number"
+// @Success 200 {object} models.Envelope{data=models.EpochSummary}
+// @Failure 400 {object} models.ErrorEnvelope "epoch is in the future / invalid request params"
+// @Failure 500 {object} models.ErrorEnvelope "internal server error"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/epochs/{epoch} [get]
+func GetEpochV2(c *gin.Context) {
+	GetEpoch(c)
+}
+
+// @Summary Get validator
+// @Description Get status, balances, lifecycle epochs and withdrawal credentials of a validator, in the v2 envelope.
+// @Description The slot and the epoch are in the meta when the state is a slot number.
+// @Tags v2
+// @Produce  json
+// @Param   id       path    string  true        "Validator index or 0x-prefixed pubkey"
+// @Param   state    query   string  false       "head (default), genesis, finalized, justified, slot number or 0x state root"
+// @Success 200 {object} models.Envelope{data=models.Validator}
+// @Failure 400 {object} models.ErrorEnvelope "invalid request params"
+// @Failure 404 {object} models.ErrorEnvelope "the validator or the state does not exist"
+// @Failure 500 {object} models.ErrorEnvelope "internal server error"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/validators/{id} [get]
+func GetValidatorV2(c *gin.Context) {
+	GetValidator(c)
+}
+
+// @Summary Get missed slots
+// @Description List the slots without a block in the given range, with the validator scheduled to propose each of them, in the v2 envelope
+// @Tags v2
+// @Produce  json
+// @Param   from     query   int     true        "First slot of the range"
+// @Param   to       query   int     true        "Last slot of the range, at most 320 slots after from"
+// @Success 200 {object} models.Envelope{data=models.MissedSlots}
+// @Failure 400 {object} models.ErrorEnvelope "slot is in the future / invalid request params"
+// @Failure 500 {object} models.ErrorEnvelope "internal server error"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/missedslots [get]
+func GetMissedSlotsV2(c *gin.Context) {
+	GetMissedSlots(c)
+}
+
+// @Summary Get withdrawals
+// @Description List the withdrawals of the blocks in [from_slot, to_slot], each a partial skim of the balance above the max or the full exit of its validator, in the v2 envelope.
+// @Description The withdrawals can be narrowed down to those of a validator or to an address.
+// @Tags v2
+// @Produce  json
+// @Param   from_slot query   int     true        "First slot of the range"
+// @Param   to_slot   query   int     true        "Last slot of the range, at most 320 slots after from_slot"
+// @Param   validator query   int     false       "Only the withdrawals of this validator index"
+// @Param   address   query   string  false       "Only the withdrawals to this execution address"
+// @Success 200 {object} models.Envelope{data=models.Withdrawals}
+// @Failure 400 {object} models.ErrorEnvelope "slot is in the future / invalid request params"
+// @Failure 500 {object} models.ErrorEnvelope "internal server error"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/withdrawals [get]
+func GetWithdrawalsV2(c *gin.Context) {
+	GetWithdrawals(c)
+}
+
+// @Summary Get the withdrawals of a block
+// @Description List the withdrawals of the block of a slot, each a partial skim of the balance above the max or the full exit of its validator, in the v2 envelope
+// @Tags v2
+// @Produce  json
+// @Param   slot     path    int     true        "Slot Number"
+// @Success 200 {object} models.Envelope{data=models.SlotWithdrawals}
+// @Header  200 {string} X-Cache "HIT when served from the store of finalized slots, MISS otherwise"
+// @Failure 400 {object} models.ErrorEnvelope "slot is in the future / invalid request params"
+// @Failure 404 {object} models.ErrorEnvelope "the slot does not exist / was missed"
+// @Failure 500 {object} models.ErrorEnvelope "internal server error"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/blocks/{slot}/withdrawals [get]
+func GetBlockWithdrawalsV2(c *gin.Context) {
+	GetBlockWithdrawals(c)
+}
+
+// @Summary Get the blobs of a block
+// @Description List the blobs of the block of a slot with their KZG commitments, versioned hashes and proofs, along with the blob gas used,
+// @Description the blob base fee and the blob fees burnt, in the v2 envelope. The proofs are left out once the node pruned the sidecars.
+// @Tags v2
+// @Produce  json
+// @Param   slot     path    int     true        "Slot Number"
+// @Success 200 {object} models.Envelope{data=models.SlotBlobs}
+// @Failure 400 {object} models.ErrorEnvelope "slot is in the future / invalid request params"
+// @Failure 404 {object} models.ErrorEnvelope "the slot does not exist / was missed"
+// @Failure 500 {object} models.ErrorEnvelope "internal server error"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/blocks/{slot}/blobs [get]
+func GetBlockBlobsV2(c *gin.Context) {
+	GetBlockBlobs(c)
+}
+
+// @Summary Stream block rewards
+// @Description The server-sent events of /v1/stream/blockrewards, every event data is a reward breakdown in the v2 envelope
+// @Tags v2
+// @Produce  text/event-stream
+// @Param   proposer_index  query   int     false       "Only blocks of this proposer"
+// @Param   fee_recipient   query   string  false       "Only blocks paying this fee recipient"
+// @Param   mev             query   bool    false       "Only MEV / non-MEV blocks"
+// @Param   Last-Event-ID   header  int     false       "Slot of the last event received"
+// @Success 200 {object} models.Envelope{data=models.RewardBreakdown}
+// @Failure 400 {object} models.ErrorEnvelope "invalid request params"
+// @Failure 503 {object} models.ErrorEnvelope "the head follower is disabled"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/stream/blockrewards [get]
+func StreamBlockRewardsV2(c *gin.Context) {
+	StreamBlockRewards(c)
+}
+
+// @Summary Stream block rewards over a WebSocket
+// @Description The WebSocket variant of /v2/stream/blockrewards: every message is a reward breakdown in the v2 envelope
+// @Tags v2
+// @Param   proposer_index  query   int     false       "Only blocks of this proposer"
+// @Param   fee_recipient   query   string  false       "Only blocks paying this fee recipient"
+// @Param   mev             query   bool    false       "Only MEV / non-MEV blocks"
+// @Param   last_event_id   query   int     false       "Slot of the last message received"
+// @Success 101 {object} models.Envelope{data=models.RewardBreakdown}
+// @Failure 400 {object} models.ErrorEnvelope "invalid request params"
+// @Failure 503 {object} models.ErrorEnvelope "the head follower is disabled"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/stream/blockrewards/ws [get]
+func StreamBlockRewardsWSV2(c *gin.Context) {
+	StreamBlockRewardsWS(c)
+}
+
+// @Summary Get the watchlist
+// @Description List the indices of the validators the alerting rules are checked for, in the v2 envelope
+// @Tags v2
+// @Produce  json
+// @Success 200 {object} models.Envelope{data=models.Watchlist}
+// @Failure 503 {object} models.ErrorEnvelope "the watchlist is disabled"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/watchlist [get]
+func GetWatchlistV2(c *gin.Context) {
+	GetWatchlist(c)
+}
+
+// @Summary Add validators to the watchlist
+// @Description Watch validators by index or pubkey, in the v2 envelope. Validators already watched are left as they are.
+// @Tags v2
+// @Accept  json
+// @Produce  json
+// @Param   request  body    models.WatchlistRequest  true  "Validator indices or pubkeys"
+// @Success 200 {object} models.Envelope{data=models.Watchlist}
+// @Failure 400 {object} models.ErrorEnvelope "invalid validator index or pubkey"
+// @Failure 404 {object} models.ErrorEnvelope "unknown pubkey"
+// @Failure 500 {object} models.ErrorEnvelope "internal server error"
+// @Failure 503 {object} models.ErrorEnvelope "the watchlist is disabled"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/watchlist [post]
+func PostWatchlistV2(c *gin.Context) {
+	PostWatchlist(c)
+}
+
+// @Summary Remove a validator from the watchlist
+// @Description Stop watching a validator, in the v2 envelope
+// @Tags v2
+// @Produce  json
+// @Param   index    path    int     true        "Validator index"
+// @Success 200 {object} models.Envelope{data=models.Watchlist}
+// @Failure 400 {object} models.ErrorEnvelope "invalid validator index"
+// @Failure 404 {object} models.ErrorEnvelope "the validator is not watched"
+// @Failure 500 {object} models.ErrorEnvelope "internal server error"
+// @Failure 503 {object} models.ErrorEnvelope "the watchlist is disabled"
+// @Failure 401 {object} models.ErrorEnvelope "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.ErrorEnvelope "rate limit or quota exceeded"
+// @Router /v2/watchlist/{index} [delete]
+func DeleteWatchlistV2(c *gin.Context) {
+	DeleteWatchlist(c)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/rewards"
+	"ethereum-validator-api/models"
+)
+
+const (
+	constFarFutureEpoch = "18446744073709551615"
+	constDefaultState   = "head"
+)
+
+var (
+	pubkeyPattern    = regexp.MustCompile(`^0x[0-9a-fA-F]{96}$`)
+	stateRootPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+	namedStates      = map[string]bool{"head": true, "genesis": true, "finalized": true, "justified": true}
+)
+
+// @Summary Get validator
+// @Description Get status, balances, lifecycle epochs and withdrawal credentials of a validator
+// @Tags validators
+// @Accept  json
+// @Produce  json
+// @Param   id       path    string  true        "Validator index or 0x-prefixed pubkey"
+// @Param   state    query   string  false       "head (default), genesis, finalized, justified, slot number or 0x state root"
+// @Success 200 {object} models.Validator
+// @Failure 400 {object} models.Error "invalid request params"
+// @Failure 404 {object} models.Error "the validator or the state does not exist"
+// @Failure 500 {object} models.Error "internal server error"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /validators/{id} [get]
+// @Router /v1/validators/{id} [get]
+func GetValidator(c *gin.Context) {
+	validatorID := c.Param("id")
+	if !pubkeyPattern.MatchString(validatorID) {
+		if _, err := strconv.ParseUint(validatorID, 10, 64); err != nil {
+			respondError(c, http.StatusBadRequest, "Invalid validator index or pubkey")
+			return
+		}
+	}
+	stateID := c.DefaultQuery("state", constDefaultState)
+	if !isValidStateID(stateID) {
+		respondError(c, http.StatusBadRequest, "Invalid state")
+		return
+	}
+	cfg, exists := c.Get("config")
+	if !exists {
+		logger(c).Error("config is missing")
+		respondError(c, http.StatusInternalServerError, "config not found")
+		return
+	}
+	appCfg := cfg.(*AppConfig)
+	if appCfg.Registry != nil && strings.HasPrefix(validatorID, "0x") {
+		if index, ok := appCfg.Registry.Index(validatorID); ok {
+			validatorID = strconv.FormatInt(index, 10)
+		}
+	}
+	client, err := newBeaconClient(c, appCfg)
+	if err != nil {
+		logger(c).WithError(err).Error("could not init beacon client")
+		respondError(c, http.StatusInternalServerError, "failed to init beacon client")
+		return
+	}
+	validatorResp, err := client.FetchValidator(stateID, validatorID)
+	if errors.Is(err, beaconadapter.ErrNotFound) {
+		respondError(c, http.StatusNotFound, "validator not found")
+		return
+	}
+	if err != nil {
+		logger(c).WithError(err).Errorf("could not fetch validator %v at state %v", validatorID, stateID)
+		respondError(c, http.StatusInternalServerError, "failed to fetch validator")
+		return
+	}
+	result, err := validatorModel(&validatorResp.Data)
+	if err != nil {
+		logger(c).WithError(err).Errorf("could not convert validator %v", validatorID)
+		respondError(c, http.StatusInternalServerError, "validator conversion failed")
+		return
+	}
+	result.ExecutionOptimistic = validatorResp.ExecutionOptimistic
+	result.Finalized = validatorResp.Finalized
+	meta := nodeMeta(c)
+	if slot, err := strconv.ParseInt(stateID, 10, 64); err == nil {
+		meta = slotMeta(c, slot, result.Finalized, result.ExecutionOptimistic)
+	}
+	meta.Finalized = &result.Finalized
+	meta.ExecutionOptimistic = &result.ExecutionOptimistic
+	respond(c, http.StatusOK, result, meta)
+}
+
+func isValidStateID(stateID string) bool {
+	if namedStates[stateID] || stateRootPattern.MatchString(stateID) {
+		return true
+	}
+	_, err := strconv.ParseUint(stateID, 10, 64)
+	return err == nil
+}
+
+func validatorModel(validator *beaconadapter.ValidatorData) (*models.Validator, error) {
+	member, err := rewards.ParseValidator(validator)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.Validator{
+		Index:                 member.Index,
+		Pubkey:                member.Pubkey,
+		Status:                member.Status,
+		Balance:               member.Balance,
+		EffectiveBalance:      member.EffectiveBalance,
+		Slashed:               member.Slashed,
+		WithdrawalCredentials: validator.Validator.WithdrawalCredentials,
+	}
+	epochs := []struct {
+		value  string
+		target **int64
+	}{
+		{validator.Validator.ActivationEligibilityEpoch, &result.ActivationEligibilityEpoch},
+		{validator.Validator.ActivationEpoch, &result.ActivationEpoch},
+		{validator.Validator.ExitEpoch, &result.ExitEpoch},
+		{validator.Validator.WithdrawableEpoch, &result.WithdrawableEpoch},
+	}
+	for _, epoch := range epochs {
+		if epoch.value == constFarFutureEpoch {
+			continue
+		}
+		parsed, err := strconv.ParseInt(epoch.value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse epoch of validator %d: %w", member.Index, err)
+		}
+		*epoch.target = &parsed
+	}
+	result.WithdrawalCredentialsType, result.WithdrawalAddress, err = beaconadapter.ParseWithdrawalCredentials(validator.Validator.WithdrawalCredentials)
+	if err != nil {
+		return nil, fmt.Errorf("validator %d: %w", member.Index, err)
+	}
+	return result, nil
+}
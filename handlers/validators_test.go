@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/models"
+)
+
+const (
+	testPubkey  = "0x933ad9491b62059dd065b560d256d8957a8c402cc6e8d8ee7290ae11e8f7329267a8811c397529dac52ae1342ba58c95"
+	testAddress = "0x9eC60c4B46e6Dd9A3a11F6e8BC8BAba88d38FD8C"
+)
+
+// validatorStandIn serves /eth/v1/beacon/states/{state}/validators/{id} for validators 1 and 2.
+func validatorStandIn() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		var body string
+		switch id {
+		case "1":
+			body = `{"index":"1","balance":"32001000000","status":"active_ongoing","validator":{"pubkey":"0xaa",` +
+				`"withdrawal_credentials":"0x010000000000000000000000` + strings.ToLower(testAddress[2:]) + `",` +
+				`"effective_balance":"32000000000","slashed":false,"activation_eligibility_epoch":"0",` +
+				`"activation_epoch":"0","exit_epoch":"18446744073709551615","withdrawable_epoch":"18446744073709551615"}}`
+		case "2", testPubkey:
+			body = `{"index":"2","balance":"0","status":"withdrawal_done","validator":{"pubkey":"` + testPubkey + `",` +
+				`"withdrawal_credentials":"0x00f50428677c60f997aadeab24aabf7fceaef491c96a52b463ae91f95611cf71",` +
+				`"effective_balance":"0","slashed":true,"activation_eligibility_epoch":"0",` +
+				`"activation_epoch":"0","exit_epoch":"200","withdrawable_epoch":"8392"}}`
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"execution_optimistic":false,"finalized":true,"data":%s}`, body)
+	}))
+}
+
+func TestGetValidator(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	node := validatorStandIn()
+	defer node.Close()
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: node.URL}))
+	router.GET("/validators/:id", GetValidator)
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		check          func(t *testing.T, v *models.Validator)
+	}{
+		{
+			name:           "Active validator with execution credentials",
+			path:           "/validators/1",
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, v *models.Validator) {
+				assert.Equal(t, int64(32001000000), v.Balance)
+				assert.Equal(t, "0x01", v.WithdrawalCredentialsType)
+				assert.Equal(t, testAddress, v.WithdrawalAddress)
+				assert.Nil(t, v.ExitEpoch)
+				require.NotNil(t, v.ActivationEpoch)
+				assert.Equal(t, int64(0), *v.ActivationEpoch)
+			},
+		},
+		{
+			name:           "Exited validator by pubkey",
+			path:           "/validators/" + testPubkey + "?state=finalized",
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, v *models.Validator) {
+				assert.Equal(t, int64(2), v.Index)
+				assert.True(t, v.Slashed)
+				assert.Equal(t, "0x00", v.WithdrawalCredentialsType)
+				assert.Empty(t, v.WithdrawalAddress)
+				require.NotNil(t, v.WithdrawableEpoch)
+				assert.Equal(t, int64(8392), *v.WithdrawableEpoch)
+			},
+		},
+		{
+			name:           "Unknown validator (404)",
+			path:           "/validators/3",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid id (400)",
+			path:           "/validators/0x1234",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid state (400)",
+			path:           "/validators/1?state=latest",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tc.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.expectedStatus, w.Code)
+			if tc.check != nil {
+				var v models.Validator
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &v))
+				tc.check(t, &v)
+			}
+		})
+	}
+}
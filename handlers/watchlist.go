@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/watch"
+	"ethereum-validator-api/models"
+)
+
+const constWatchlistDisabled = "watchlist is disabled"
+
+// @Summary Get the watchlist
+// @Description List the indices of the validators the alerting rules are checked for
+// @Tags watchlist
+// @Produce  json
+// @Success 200 {object} models.Watchlist
+// @Failure 503 {object} models.Error "the watchlist is disabled"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /watchlist [get]
+// @Router /v1/watchlist [get]
+func GetWatchlist(c *gin.Context) {
+	appCfg, ok := watchlistConfig(c)
+	if !ok {
+		return
+	}
+	respond(c, http.StatusOK, models.Watchlist{Validators: appCfg.Watchlist.Indices()}, nil)
+}
+
+// @Summary Add validators to the watchlist
+// @Description Watch validators by index or pubkey. Validators already watched are left as they are.
+// @Tags watchlist
+// @Accept  json
+// @Produce  json
+// @Param   request  body    models.WatchlistRequest  true  "Validator indices or pubkeys"
+// @Success 200 {object} models.Watchlist
+// @Failure 400 {object} models.Error "invalid validator index or pubkey"
+// @Failure 404 {object} models.Error "unknown pubkey"
+// @Failure 500 {object} models.Error "internal server error"
+// @Failure 503 {object} models.Error "the watchlist is disabled"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /watchlist [post]
+// @Router /v1/watchlist [post]
+func PostWatchlist(c *gin.Context) {
+	var request models.WatchlistRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	appCfg, ok := watchlistConfig(c)
+	if !ok {
+		return
+	}
+	client, err := newBeaconClient(c, appCfg)
+	if err != nil {
+		logger(c).WithError(err).Error("could not init beacon client")
+		respondError(c, http.StatusInternalServerError, "failed to init beacon client")
+		return
+	}
+	indices := make([]int64, 0, len(request.Validators))
+	for _, id := range request.Validators {
+		index, err := watch.ResolveIndex(client, appCfg.Registry, id)
+		if errors.Is(err, beaconadapter.ErrNotFound) {
+			respondError(c, http.StatusNotFound, "validator not found: "+id)
+			return
+		}
+		if err != nil {
+			logger(c).WithError(err).Warnf("could not resolve validator %v", id)
+			respondError(c, http.StatusBadRequest, "Invalid validator index or pubkey: "+id)
+			return
+		}
+		indices = append(indices, index)
+	}
+	if err := appCfg.Watchlist.Add(indices...); err != nil {
+		logger(c).WithError(err).Error("failed to save the watchlist")
+		respondError(c, http.StatusInternalServerError, "failed to save the watchlist")
+		return
+	}
+	respond(c, http.StatusOK, models.Watchlist{Validators: appCfg.Watchlist.Indices()}, nil)
+}
+
+// @Summary Remove a validator from the watchlist
+// @Tags watchlist
+// @Produce  json
+// @Param   index    path    int     true        "Validator index"
+// @Success 200 {object} models.Watchlist
+// @Failure 400 {object} models.Error "invalid validator index"
+// @Failure 404 {object} models.Error "the validator is not watched"
+// @Failure 500 {object} models.Error "internal server error"
+// @Failure 503 {object} models.Error "the watchlist is disabled"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /watchlist/{index} [delete]
+// @Router /v1/watchlist/{index} [delete]
+func DeleteWatchlist(c *gin.Context) {
+	index, err := strconv.ParseInt(c.Param("index"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "Invalid validator index")
+		return
+	}
+	appCfg, ok := watchlistConfig(c)
+	if !ok {
+		return
+	}
+	removed, err := appCfg.Watchlist.Remove(index)
+	if err != nil {
+		logger(c).WithError(err).Error("failed to save the watchlist")
+		respondError(c, http.StatusInternalServerError, "failed to save the watchlist")
+		return
+	}
+	if !removed {
+		respondError(c, http.StatusNotFound, "validator is not watched")
+		return
+	}
+	respond(c, http.StatusOK, models.Watchlist{Validators: appCfg.Watchlist.Indices()}, nil)
+}
+
+func watchlistConfig(c *gin.Context) (*AppConfig, bool) {
+	cfg, exists := c.Get("config")
+	if !exists {
+		logger(c).Error("config is missing")
+		respondError(c, http.StatusInternalServerError, "config not found")
+		return nil, false
+	}
+	appCfg := cfg.(*AppConfig)
+	if appCfg.Watchlist == nil {
+		respondError(c, http.StatusServiceUnavailable, constWatchlistDisabled)
+		return nil, false
+	}
+	return appCfg, true
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/watch"
+	"ethereum-validator-api/models"
+)
+
+func TestWatchlist(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	node := validatorStandIn()
+	defer node.Close()
+	watchlist, err := watch.NewWatchlist(nil)
+	require.NoError(t, err)
+	router := gin.New()
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: node.URL, Watchlist: watchlist}))
+	router.GET("/watchlist", GetWatchlist)
+	router.POST("/watchlist", PostWatchlist)
+	router.DELETE("/watchlist/:index", DeleteWatchlist)
+
+	request := func(method, path, body string) (int, *models.Watchlist) {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			return w.Code, nil
+		}
+		var result models.Watchlist
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		return w.Code, &result
+	}
+
+	code, result := request("POST", "/watchlist", `{"validators":["7","`+testPubkey+`"]}`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []int64{2, 7}, result.Validators)
+
+	code, _ = request("POST", "/watchlist", `{"validators":["0x`+strings.Repeat("ab", 48)+`"]}`)
+	require.Equal(t, http.StatusNotFound, code)
+	code, _ = request("POST", "/watchlist", `{"validators":["seven"]}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = request("POST", "/watchlist", `{}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, result = request("DELETE", "/watchlist/7", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []int64{2}, result.Validators)
+	code, _ = request("DELETE", "/watchlist/7", "")
+	require.Equal(t, http.StatusNotFound, code)
+
+	code, result = request("GET", "/watchlist", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []int64{2}, result.Validators)
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/metrics"
+	"ethereum-validator-api/internal/rewards"
+	"ethereum-validator-api/models"
+)
+
+const constWithdrawalsCache = "withdrawals"
+
+// @Summary Get the withdrawals of a block
+// @Description List the withdrawals of the block of a slot, each a partial skim of the balance above the max or the full exit of its validator
+// @Tags withdrawals
+// @Produce  json
+// @Param   slot     path    int     true        "Slot Number"
+// @Success 200 {object} models.SlotWithdrawals
+// @Header  200 {string} X-Cache "HIT when served from the store of finalized slots, MISS otherwise"
+// @Failure 400 {object} models.Error "slot is in the future / invalid request params"
+// @Failure 404 {object} models.Error "the slot does not exist / was missed"
+// @Failure 500 {object} models.Error "internal server error"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /blocks/{slot}/withdrawals [get]
+// @Router /v1/blocks/{slot}/withdrawals [get]
+func GetBlockWithdrawals(c *gin.Context) {
+	slot, err := strconv.ParseInt(c.Param("slot"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, constInvalidSlotNumber)
+		return
+	}
+	cfg, exists := c.Get("config")
+	if !exists {
+		logger(c).Error("config is missing")
+		respondError(c, http.StatusInternalServerError, "config not found")
+		return
+	}
+	appCfg := cfg.(*AppConfig)
+	client, err := newBeaconClient(c, appCfg)
+	if err != nil {
+		logger(c).WithError(err).Error("could not init beacon client")
+		respondError(c, http.StatusInternalServerError, "failed to init beacon client")
+		return
+	}
+	if client.MapSlotToTimestamp(slot).After(time.Now()) {
+		respondError(c, http.StatusBadRequest, constSlotInFuture)
+		return
+	}
+	if slot < 0 {
+		// there are no blocks before genesis
+		respondError(c, http.StatusNotFound, constBlockNotFound)
+		return
+	}
+	withdrawals, err := slotsWithdrawals(c.Request.Context(), appCfg, client, []int64{slot})
+	if err != nil {
+		logger(c).WithError(err).Errorf("could not get the withdrawals of slot %v", slot)
+		respondError(c, http.StatusInternalServerError, "failed to get the withdrawals")
+		return
+	}
+	if withdrawals[0] == nil {
+		respondError(c, http.StatusNotFound, constBlockNotFound)
+		return
+	}
+	if appCfg.Store != nil {
+		c.Header(constCacheHeader, constCacheMiss)
+		if withdrawals[0].stored {
+			c.Header(constCacheHeader, constCacheHit)
+		}
+	}
+	result := withdrawals[0].SlotWithdrawals
+	respond(c, http.StatusOK, result, slotMeta(c, slot, result.Finalized, result.ExecutionOptimistic))
+}
+
+// @Summary Get withdrawals
+// @Description List the withdrawals of the blocks in [from_slot, to_slot], each a partial skim of the balance above the max or the full exit of its validator.
+// @Description The withdrawals can be narrowed down to those of a validator or to an address.
+// @Tags withdrawals
+// @Produce  json
+// @Param   from_slot query   int     true        "First slot of the range"
+// @Param   to_slot   query   int     true        "Last slot of the range, at most 320 slots after from_slot"
+// @Param   validator query   int     false       "Only the withdrawals of this validator index"
+// @Param   address   query   string  false       "Only the withdrawals to this execution address"
+// @Success 200 {object} models.Withdrawals
+// @Failure 400 {object} models.Error "slot is in the future / invalid request params"
+// @Failure 500 {object} models.Error "internal server error"
+// @Failure 401 {object} models.Error "missing or invalid API key, when keys are required"
+// @Failure 429 {object} models.Error "rate limit or quota exceeded"
+// @Router /withdrawals [get]
+// @Router /v1/withdrawals [get]
+func GetWithdrawals(c *gin.Context) {
+	from, err := strconv.ParseInt(c.Query("from_slot"), 10, 64)
+	if err != nil || from < 0 {
+		respondError(c, http.StatusBadRequest, constInvalidSlotNumber)
+		return
+	}
+	to, err := strconv.ParseInt(c.Query("to_slot"), 10, 64)
+	if err != nil || to < from {
+		respondError(c, http.StatusBadRequest, constInvalidSlotNumber)
+		return
+	}
+	if to-from+1 > constMaxSlotRange {
+		respondError(c, http.StatusBadRequest, "Slot range is too wide")
+		return
+	}
+	validator := int64(-1)
+	if value, ok := c.GetQuery("validator"); ok {
+		if validator, err = strconv.ParseInt(value, 10, 64); err != nil || validator < 0 {
+			respondError(c, http.StatusBadRequest, "Invalid validator index")
+			return
+		}
+	}
+	address := c.Query("address")
+	if address != "" && !common.IsHexAddress(address) {
+		respondError(c, http.StatusBadRequest, "Invalid address")
+		return
+	}
+	cfg, exists := c.Get("config")
+	if !exists {
+		logger(c).Error("config is missing")
+		respondError(c, http.StatusInternalServerError, "config not found")
+		return
+	}
+	appCfg := cfg.(*AppConfig)
+	client, err := newBeaconClient(c, appCfg)
+	if err != nil {
+		logger(c).WithError(err).Error("could not init beacon client")
+		respondError(c, http.StatusInternalServerError, "failed to init beacon client")
+		return
+	}
+	if client.MapSlotToTimestamp(to).After(time.Now()) {
+		respondError(c, http.StatusBadRequest, constSlotInFuture)
+		return
+	}
+	slots := make([]int64, 0, to-from+1)
+	for slot := from; slot <= to; slot++ {
+		slots = append(slots, slot)
+	}
+	clearWriteDeadline(c)
+	blocks, err := slotsWithdrawals(c.Request.Context(), appCfg, client, slots)
+	if err != nil {
+		logger(c).WithError(err).Errorf("could not get the withdrawals of %v-%v", from, to)
+		respondError(c, http.StatusInternalServerError, "failed to get the withdrawals")
+		return
+	}
+	result := models.Withdrawals{FromSlot: from, ToSlot: to, Withdrawals: []models.Withdrawal{}, Finalized: true}
+	for _, block := range blocks {
+		if block == nil {
+			continue
+		}
+		result.Finalized = result.Finalized && block.Finalized
+		for _, withdrawal := range block.Withdrawals {
+			if validator >= 0 && withdrawal.ValidatorIndex != validator {
+				continue
+			}
+			if address != "" && !strings.EqualFold(withdrawal.Address, address) {
+				continue
+			}
+			result.Withdrawals = append(result.Withdrawals, withdrawal)
+		}
+	}
+	respond(c, http.StatusOK, result, nodeMeta(c))
+}
+
+// blockWithdrawals are the withdrawals of a block, stored is true when they come from the store.
+type blockWithdrawals struct {
+	*models.SlotWithdrawals
+	stored bool
+}
+
+// slotsWithdrawals returns the withdrawals of the blocks of the slots, nil for the missed slots.
+// The finalized blocks are read from the store, the others are fetched with a pool of workers,
+// classified in one go and stored once finalized. It stops fetching when ctx is done.
+func slotsWithdrawals(ctx context.Context, appCfg *AppConfig, client *beaconadapter.BeaconClient,
+	slots []int64) ([]*blockWithdrawals, error) {
+	results := make([]*blockWithdrawals, len(slots))
+	errs := make([]error, len(slots))
+	if err := parallelContext(ctx, len(slots), appCfg.BatchWorkers, func(i int) {
+		if appCfg.Store != nil {
+			stored, err := appCfg.Store.GetWithdrawals(slots[i])
+			if err != nil {
+				logrus.WithContext(ctx).WithError(err).Warnf("failed to read the stored withdrawals of slot %v", slots[i])
+			}
+			metrics.ObserveCache(constWithdrawalsCache, stored != nil)
+			if stored != nil {
+				results[i] = &blockWithdrawals{SlotWithdrawals: stored, stored: true}
+				return
+			}
+		}
+		blockResp, err := client.FetchBlockResponse(slots[i])
+		if errors.Is(err, beaconadapter.ErrNotFound) {
+			return
+		}
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to fetch the block of slot %d: %w", slots[i], err)
+			return
+		}
+		withdrawals, err := rewards.BlockWithdrawals(blockResp)
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to map the withdrawals of slot %d: %w", slots[i], err)
+			return
+		}
+		results[i] = &blockWithdrawals{SlotWithdrawals: withdrawals}
+	}); err != nil {
+		return nil, err
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	// the validators of all the fetched blocks are looked up at once
+	var fetched []models.Withdrawal
+	for _, result := range results {
+		if result != nil && !result.stored {
+			fetched = append(fetched, result.Withdrawals...)
+		}
+	}
+	if err := rewards.ClassifyWithdrawals(client, fetched); err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if result == nil || result.stored {
+			continue
+		}
+		copy(result.Withdrawals, fetched)
+		fetched = fetched[len(result.Withdrawals):]
+		if appCfg.Store != nil && result.Finalized {
+			if err := appCfg.Store.PutWithdrawals(result.SlotWithdrawals); err != nil {
+				logrus.WithContext(ctx).WithError(err).Warnf("failed to store the withdrawals of slot %v", result.Slot)
+			}
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/models"
+)
+
+func TestWithdrawals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+	// validator 8 is withdrawable from epoch 2 on
+	blocks := map[string]string{
+		"100": `"finalized":true,"data":{"message":{"slot":"100","body":{"execution_payload":{"block_number":"900","withdrawals":[` +
+			`{"index":"41","validator_index":"7","address":"0xAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAa","amount":"17000000"},` +
+			`{"index":"42","validator_index":"8","address":"0xBbBbBbBbBbBbBbBbBbBbBbBbBbBbBbBbBbBbBbBb","amount":"32001000000"}]}}}}`,
+		"102": `"finalized":true,"data":{"message":{"slot":"102","body":{"execution_payload":{"block_number":"901","withdrawals":[]}}}}`,
+		"140": `"finalized":false,"data":{"message":{"slot":"140","body":{"execution_payload":{"block_number":"935","withdrawals":[` +
+			`{"index":"43","validator_index":"7","address":"0xAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAa","amount":"18000000"}]}}}}`,
+	}
+	var blockRequests int
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/finality_checkpoints"):
+			fmt.Fprint(w, `{"data":{"finalized":{"epoch":"4","root":"0x00"}}}`)
+		case strings.HasSuffix(r.URL.Path, "/states/63/validators"):
+			// the balance of validator 8 when it became withdrawable
+			fmt.Fprint(w, `{"data":[{"index":"8","balance":"32001000000","validator":{"withdrawable_epoch":"2"}}]}`)
+		case strings.HasSuffix(r.URL.Path, "/validators"):
+			fmt.Fprint(w, `{"data":[{"index":"7","validator":{"withdrawable_epoch":"18446744073709551615"}},`+
+				`{"index":"8","validator":{"withdrawable_epoch":"2"}}]}`)
+		case strings.Contains(r.URL.Path, "/beacon/blocks/"):
+			blockRequests++
+			block, ok := blocks[last]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"version":"deneb",%s}`, block)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer node.Close()
+	router := gin.New()
+	// a single worker, the stand-in counts the requests
+	router.Use(ConfigMiddleware(&AppConfig{BaseURL: node.URL, Store: s, BatchWorkers: 1}))
+	router.GET("/withdrawals", GetWithdrawals)
+	router.GET("/blocks/:slot/withdrawals", GetBlockWithdrawals)
+	request := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	partial := models.Withdrawal{Index: 41, Slot: 100, ValidatorIndex: 7, Address: "0xAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAaAa", Amount: 17000000, Kind: "partial"}
+	full := models.Withdrawal{Index: 42, Slot: 100, ValidatorIndex: 8, Address: "0xBbBbBbBbBbBbBbBbBbBbBbBbBbBbBbBbBbBbBbBb", Amount: 32001000000, Kind: "full"}
+
+	t.Run("Withdrawals of a block", func(t *testing.T) {
+		w := request("/blocks/100/withdrawals")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, constCacheMiss, w.Header().Get(constCacheHeader))
+		var result models.SlotWithdrawals
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		require.Equal(t, models.SlotWithdrawals{Slot: 100, BlockNumber: 900, Withdrawals: []models.Withdrawal{partial, full}, Finalized: true}, result)
+
+		// the finalized block was stored
+		blockRequests = 0
+		w = request("/blocks/100/withdrawals")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, constCacheHit, w.Header().Get(constCacheHeader))
+		require.Zero(t, blockRequests)
+	})
+
+	t.Run("Withdrawals of a range", func(t *testing.T) {
+		var result models.Withdrawals
+		w := request("/withdrawals?from_slot=100&to_slot=140")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		require.Len(t, result.Withdrawals, 3)
+		require.Equal(t, int64(43), result.Withdrawals[2].Index)
+		require.Equal(t, "partial", result.Withdrawals[2].Kind)
+		require.False(t, result.Finalized)
+
+		w = request("/withdrawals?from_slot=100&to_slot=110&address=0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		require.Equal(t, []models.Withdrawal{full}, result.Withdrawals)
+		require.True(t, result.Finalized)
+
+		w = request("/withdrawals?from_slot=100&to_slot=140&validator=7")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+		require.Len(t, result.Withdrawals, 2)
+
+		// the block of slot 140 isn't finalized, so it wasn't stored
+		stored, err := s.GetWithdrawals(140)
+		require.NoError(t, err)
+		require.Nil(t, stored)
+	})
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{"Missed slot (404)", "/blocks/101/withdrawals", http.StatusNotFound},
+		{"Invalid slot (400)", "/blocks/abc/withdrawals", http.StatusBadRequest},
+		{"Future slot (400)", "/blocks/4503137824400/withdrawals", http.StatusBadRequest},
+		{"Range too wide (400)", "/withdrawals?from_slot=0&to_slot=1000", http.StatusBadRequest},
+		{"Invalid validator (400)", "/withdrawals?from_slot=100&to_slot=110&validator=x", http.StatusBadRequest},
+		{"Invalid address (400)", "/withdrawals?from_slot=100&to_slot=110&address=0x12", http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedStatus, request(tc.path).Code)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"ethereum-validator-api/internal/rewards"
+)
+
+var (
+	ErrMissingKey = errors.New("API key is missing")
+	ErrInvalidKey = errors.New("API key is invalid")
+)
+
+// Key is an API key as configured: only the SHA-256 hash of the key itself is kept.
+type Key struct {
+	Name string `mapstructure:"name"`
+	// Hash is the hex SHA-256 of the key
+	Hash string `mapstructure:"hash"`
+	// RateLimit is the number of requests per window, 0 for the default
+	RateLimit int `mapstructure:"rate_limit"`
+	// Quota is the cost allowed per quota period, 0 for the default
+	Quota int `mapstructure:"quota"`
+	// Modes are the reward modes the key may request, light and/or full; all of them when empty
+	Modes []string `mapstructure:"modes"`
+}
+
+// AllowsMode tells whether the key may request the reward mode, one of rewards.ModeLight and rewards.ModeBeast.
+func (k *Key) AllowsMode(mode string) bool {
+	if len(k.Modes) == 0 {
+		return true
+	}
+	for _, allowed := range k.Modes {
+		if parsed, err := rewards.ParseMode(allowed); err == nil && parsed == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// Keyring looks the API keys up by their hash.
+type Keyring struct {
+	keys map[string]*Key
+}
+
+// NewKeyring indexes the keys, along with the ones of keysFile when set. The file is YAML or JSON
+// with the keys under "keys", in the same format as the config.
+func NewKeyring(keys []Key, keysFile string) (*Keyring, error) {
+	if keysFile != "" {
+		v := viper.New()
+		v.SetConfigFile(keysFile)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read the API keys file: %w", err)
+		}
+		var fileKeys []Key
+		if err := v.UnmarshalKey("keys", &fileKeys); err != nil {
+			return nil, fmt.Errorf("failed to parse the API keys file: %w", err)
+		}
+		keys = append(keys, fileKeys...)
+	}
+	k := &Keyring{keys: make(map[string]*Key, len(keys))}
+	for i := range keys {
+		key := &keys[i]
+		key.Hash = strings.ToLower(strings.TrimPrefix(key.Hash, "sha256:"))
+		if decoded, err := hex.DecodeString(key.Hash); err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("API key %q has no valid SHA-256 hash", key.Name)
+		}
+		for _, mode := range key.Modes {
+			if _, err := rewards.ParseMode(mode); err != nil {
+				return nil, fmt.Errorf("API key %q: %w", key.Name, err)
+			}
+		}
+		if _, ok := k.keys[key.Hash]; ok {
+			return nil, fmt.Errorf("API key %q is configured twice", key.Name)
+		}
+		k.keys[key.Hash] = key
+	}
+	return k, nil
+}
+
+// Lookup returns the key configured for the raw API key.
+func (k *Keyring) Lookup(apiKey string) (*Key, error) {
+	if apiKey == "" {
+		return nil, ErrMissingKey
+	}
+	key, ok := k.keys[HashKey(apiKey)]
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+// Len returns the number of keys.
+func (k *Keyring) Len() int {
+	return len(k.keys)
+}
+
+// HashKey returns the hash of the API key as configured.
+func HashKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateKey returns a new random API key.
+func GenerateKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate an API key: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/rewards"
+)
+
+func TestKeyring(t *testing.T) {
+	fileKey, err := GenerateKey()
+	require.NoError(t, err)
+	keysFile := filepath.Join(t.TempDir(), "keys.yaml")
+	require.NoError(t, os.WriteFile(keysFile, []byte("keys:\n  - name: file\n    hash: "+HashKey(fileKey)+"\n    quota: 50\n"), 0o600))
+
+	keyring, err := NewKeyring([]Key{{Name: "config", Hash: "sha256:" + HashKey("secret"), RateLimit: 5}}, keysFile)
+	require.NoError(t, err)
+	require.Equal(t, 2, keyring.Len())
+
+	key, err := keyring.Lookup("secret")
+	require.NoError(t, err)
+	require.Equal(t, "config", key.Name)
+	require.Equal(t, 5, key.RateLimit)
+	key, err = keyring.Lookup(fileKey)
+	require.NoError(t, err)
+	require.Equal(t, "file", key.Name)
+	require.Equal(t, 50, key.Quota)
+
+	_, err = keyring.Lookup("")
+	require.ErrorIs(t, err, ErrMissingKey)
+	_, err = keyring.Lookup("guess")
+	require.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = NewKeyring([]Key{{Name: "plain", Hash: "secret"}}, "")
+	require.Error(t, err)
+	_, err = NewKeyring([]Key{{Name: "a", Hash: HashKey("secret")}, {Name: "b", Hash: HashKey("secret")}}, "")
+	require.Error(t, err)
+	_, err = NewKeyring(nil, filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestKeyModes(t *testing.T) {
+	keyring, err := NewKeyring([]Key{
+		{Name: "light", Hash: HashKey("light"), Modes: []string{"light"}},
+		{Name: "any", Hash: HashKey("any")},
+	}, "")
+	require.NoError(t, err)
+	key, err := keyring.Lookup("light")
+	require.NoError(t, err)
+	require.True(t, key.AllowsMode(rewards.ModeLight))
+	require.False(t, key.AllowsMode(rewards.ModeBeast))
+	key, err = keyring.Lookup("any")
+	require.NoError(t, err)
+	require.True(t, key.AllowsMode(rewards.ModeBeast))
+
+	_, err = NewKeyring([]Key{{Name: "turbo", Hash: HashKey("turbo"), Modes: []string{"turbo"}}}, "")
+	require.ErrorIs(t, err, rewards.ErrUnknownMode)
+}
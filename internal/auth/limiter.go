@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+const constAnonymous = "anonymous"
+
+// Config holds the API key settings and the limits.
+type Config struct {
+	// Enabled requires an API key on every API request
+	Enabled  bool   `mapstructure:"enabled"`
+	Keys     []Key  `mapstructure:"keys"`
+	KeysFile string `mapstructure:"keys_file"`
+	// Window is the period of the rate limits
+	Window time.Duration `mapstructure:"window"`
+	// KeyRateLimit is the default number of requests per window of a key, 0 for no limit
+	KeyRateLimit int `mapstructure:"key_rate_limit"`
+	// IPRateLimit is the number of requests per window of a client address, 0 for no limit
+	IPRateLimit int `mapstructure:"ip_rate_limit"`
+	// QuotaPeriod is the period of the quotas
+	QuotaPeriod time.Duration `mapstructure:"quota_period"`
+	// Quota is the default cost allowed per quota period of a key, 0 for no quota
+	Quota int `mapstructure:"quota"`
+	// Costs weighs the requests against the quotas by endpoint, the "default" entry applies to the others
+	Costs map[string]int `mapstructure:"costs"`
+}
+
+// Usage is the state of a limit after a request.
+type Usage struct {
+	Limit     int
+	Remaining int
+	// Reset is the time left until the window ends
+	Reset time.Duration
+}
+
+// Decision tells whether a request may go on, along with the limits it was checked against.
+type Decision struct {
+	Allowed bool
+	// RateLimit is the most constraining rate limit, nil when no rate limit applies
+	RateLimit *Usage
+	// Quota is the quota of the key, nil when it has none
+	Quota *Usage
+	// QuotaExceeded tells whether it's the quota that denied the request
+	QuotaExceeded bool
+}
+
+// RetryAfter is how long the client has to wait before its next request may be allowed.
+func (d *Decision) RetryAfter() time.Duration {
+	if d.QuotaExceeded {
+		return d.Quota.Reset
+	}
+	if d.RateLimit != nil {
+		return d.RateLimit.Reset
+	}
+	return 0
+}
+
+// Limiter applies the per key and per client address rate limits and the quotas of the keys.
+// The counters live in memory, they start over when the server restarts.
+type Limiter struct {
+	cfg Config
+
+	mu     sync.Mutex
+	keys   *windowCounter
+	ips    *windowCounter
+	quotas *windowCounter
+}
+
+func NewLimiter(cfg Config) *Limiter {
+	if cfg.Window <= 0 {
+		cfg.Window = time.Minute
+	}
+	if cfg.QuotaPeriod <= 0 {
+		cfg.QuotaPeriod = 24 * time.Hour
+	}
+	return &Limiter{
+		cfg:    cfg,
+		keys:   newWindowCounter(cfg.Window),
+		ips:    newWindowCounter(cfg.Window),
+		quotas: newWindowCounter(cfg.QuotaPeriod),
+	}
+}
+
+// Cost returns the weight of the endpoint against the quotas.
+func (l *Limiter) Cost(endpoint string) int {
+	if cost, ok := l.cfg.Costs[endpoint]; ok {
+		return cost
+	}
+	if cost, ok := l.cfg.Costs["default"]; ok {
+		return cost
+	}
+	return 1
+}
+
+// Allow counts a request of the given cost from the client address, made with key or without one when nil.
+// Denied requests are not counted.
+func (l *Limiter) Allow(key *Key, ip string, cost int) *Decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	decision := &Decision{Allowed: true}
+	keyName, keyRateLimit, quota := constAnonymous, 0, 0
+	if key != nil {
+		keyName, keyRateLimit, quota = key.Hash, l.cfg.KeyRateLimit, l.cfg.Quota
+		if key.RateLimit > 0 {
+			keyRateLimit = key.RateLimit
+		}
+		if key.Quota > 0 {
+			quota = key.Quota
+		}
+	}
+
+	// the counters are only taken once all the limits allow the request
+	var checks []func()
+	check := func(counter *windowCounter, client string, limit, weight int) *Usage {
+		usage, ok := counter.peek(client, limit, weight, now)
+		if !ok {
+			decision.Allowed = false
+		}
+		checks = append(checks, func() {
+			counter.take(client, weight, now)
+			usage.Remaining -= weight
+		})
+		return usage
+	}
+	if l.cfg.IPRateLimit > 0 {
+		decision.RateLimit = check(l.ips, ip, l.cfg.IPRateLimit, 1)
+	}
+	if key != nil && keyRateLimit > 0 {
+		usage := check(l.keys, keyName, keyRateLimit, 1)
+		if decision.RateLimit == nil || usage.Remaining < decision.RateLimit.Remaining {
+			decision.RateLimit = usage
+		}
+	}
+	rateLimited := !decision.Allowed
+	if key != nil && quota > 0 {
+		decision.Quota = check(l.quotas, keyName, quota, cost)
+		decision.QuotaExceeded = !rateLimited && !decision.Allowed
+	}
+	if decision.Allowed {
+		for _, take := range checks {
+			take()
+		}
+	}
+	return decision
+}
+
+// windowCounter counts the use of every client in fixed windows. It's guarded by the lock of the limiter.
+type windowCounter struct {
+	window time.Duration
+	counts map[string]*windowCount
+	// lastSweep is when the ended windows were last dropped
+	lastSweep time.Time
+}
+
+type windowCount struct {
+	start time.Time
+	used  int
+}
+
+func newWindowCounter(window time.Duration) *windowCounter {
+	return &windowCounter{window: window, counts: make(map[string]*windowCount)}
+}
+
+// peek returns the usage of the client and whether weight more fits in the limit.
+func (w *windowCounter) peek(client string, limit, weight int, now time.Time) (*Usage, bool) {
+	count := w.current(client, now)
+	usage := &Usage{Limit: limit, Remaining: max(limit-count.used, 0), Reset: count.start.Add(w.window).Sub(now)}
+	return usage, count.used+weight <= limit
+}
+
+func (w *windowCounter) take(client string, weight int, now time.Time) {
+	w.current(client, now).used += weight
+}
+
+// current returns the count of the client in the window of now, dropping the ended windows now and then.
+func (w *windowCounter) current(client string, now time.Time) *windowCount {
+	if now.Sub(w.lastSweep) > w.window {
+		for c, count := range w.counts {
+			if now.Sub(count.start) >= w.window {
+				delete(w.counts, c)
+			}
+		}
+		w.lastSweep = now
+	}
+	count, ok := w.counts[client]
+	if !ok || now.Sub(count.start) >= w.window {
+		count = &windowCount{start: now}
+		w.counts[client] = count
+	}
+	return count
+}
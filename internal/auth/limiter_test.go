@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiterIPRateLimit(t *testing.T) {
+	limiter := NewLimiter(Config{Window: 100 * time.Millisecond, IPRateLimit: 2})
+	for remaining := 1; remaining >= 0; remaining-- {
+		decision := limiter.Allow(nil, "10.0.0.1", 1)
+		require.True(t, decision.Allowed)
+		require.Equal(t, 2, decision.RateLimit.Limit)
+		require.Equal(t, remaining, decision.RateLimit.Remaining)
+		require.Nil(t, decision.Quota)
+	}
+	decision := limiter.Allow(nil, "10.0.0.1", 1)
+	require.False(t, decision.Allowed)
+	require.False(t, decision.QuotaExceeded)
+	require.Positive(t, decision.RetryAfter())
+	// other clients have their own limit
+	require.True(t, limiter.Allow(nil, "10.0.0.2", 1).Allowed)
+
+	time.Sleep(100 * time.Millisecond)
+	require.True(t, limiter.Allow(nil, "10.0.0.1", 1).Allowed)
+}
+
+func TestLimiterKeyRateLimit(t *testing.T) {
+	limiter := NewLimiter(Config{Window: time.Minute, KeyRateLimit: 1, IPRateLimit: 10})
+	key := &Key{Name: "default", Hash: HashKey("a")}
+	premium := &Key{Name: "premium", Hash: HashKey("b"), RateLimit: 3}
+
+	decision := limiter.Allow(key, "10.0.0.1", 1)
+	require.True(t, decision.Allowed)
+	// the key limit is the most constraining one
+	require.Equal(t, 1, decision.RateLimit.Limit)
+	require.Equal(t, 0, decision.RateLimit.Remaining)
+	require.False(t, limiter.Allow(key, "10.0.0.2", 1).Allowed)
+
+	for range 3 {
+		require.True(t, limiter.Allow(premium, "10.0.0.1", 1).Allowed)
+	}
+	require.False(t, limiter.Allow(premium, "10.0.0.1", 1).Allowed)
+	// the denied requests didn't count against the address
+	decision = limiter.Allow(nil, "10.0.0.1", 1)
+	require.True(t, decision.Allowed)
+	require.Equal(t, 10-1-3-1, decision.RateLimit.Remaining)
+}
+
+func TestLimiterQuota(t *testing.T) {
+	limiter := NewLimiter(Config{
+		QuotaPeriod: time.Hour,
+		Quota:       25,
+		Costs:       map[string]int{"blockreward_beast": 20, "default": 2},
+	})
+	require.Equal(t, 20, limiter.Cost("blockreward_beast"))
+	require.Equal(t, 2, limiter.Cost("syncduties"))
+	key := &Key{Name: "default", Hash: HashKey("a")}
+
+	decision := limiter.Allow(key, "10.0.0.1", limiter.Cost("blockreward_beast"))
+	require.True(t, decision.Allowed)
+	require.Nil(t, decision.RateLimit)
+	require.Equal(t, 25, decision.Quota.Limit)
+	require.Equal(t, 5, decision.Quota.Remaining)
+
+	decision = limiter.Allow(key, "10.0.0.1", limiter.Cost("blockreward_beast"))
+	require.False(t, decision.Allowed)
+	require.True(t, decision.QuotaExceeded)
+	require.InDelta(t, time.Hour, decision.RetryAfter(), float64(time.Minute))
+	// cheaper requests still fit
+	decision = limiter.Allow(key, "10.0.0.1", limiter.Cost("syncduties"))
+	require.True(t, decision.Allowed)
+	require.Equal(t, 3, decision.Quota.Remaining)
+
+	// requests without a key have no quota
+	require.Nil(t, limiter.Allow(nil, "10.0.0.1", 20).Quota)
+}
@@ -2,47 +2,103 @@ package beaconadapter
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"ethereum-validator-api/internal/metrics"
+	"ethereum-validator-api/internal/tracing"
 )
 
 const (
 	constSyncDutiesPath     = "/eth/v1/beacon/states/%v/sync_committees"
 	constBlockPath          = "/eth/v2/beacon/blocks/%v"
 	constValidatorPath      = "/eth/v1/beacon/states/%v/validators"
+	constFinalityPath       = "/eth/v1/beacon/states/%v/finality_checkpoints"
+	constSingleValidator    = "/eth/v1/beacon/states/%v/validators/%v"
+	constHeaderPath         = "/eth/v1/beacon/headers/%v"
+	constProposerDutiesPath = "/eth/v1/validator/duties/proposer/%v"
+	constSyncingPath        = "/eth/v1/node/syncing"
 	constSyncDutiesRewards  = "/eth/v1/beacon/rewards/sync_committee/%v"
 	constAttestationRewards = "/eth/v1/beacon/rewards/attestations/%v"
 	constBlockRewards       = "eth/v1/beacon/rewards/blocks/%v"
+	constBlobSidecarsPath   = "/eth/v1/beacon/blob_sidecars/%v"
 	constRewardsHistory     = "https://beaconcha.in/api/v1/validator/%v/incomedetailhistory?latest_epoch=%v&limit=1"
 	EthereumSlotDuration    = 12
+	EthereumSlotsPerEpoch   = 32
+	// EpochsPerSyncCommitteePeriod is EPOCHS_PER_SYNC_COMMITTEE_PERIOD from the Altair spec
+	EpochsPerSyncCommitteePeriod = 256
+
+	constValidatorChunkSize    = 100
+	constMaxConcurrentRequests = 4
 )
 
 var (
 	EthereumMainnetGenesisTime = time.Date(2020, 12, 1, 12, 0, 23, 0, time.UTC)
+
+	ErrNotFound = errors.New("not found")
+	// ErrProposersUnavailable is a node refusing the proposer duties of an epoch, which most nodes
+	// only serve around the head unless they keep the historical states
+	ErrProposersUnavailable = errors.New("proposer duties are not available")
+
+	errMethodUnsupported = errors.New("method is not supported by the node")
+	// errPostRejected is a 404 or a 400 to the POST form, which the nodes without it answer as well
+	errPostRejected = errors.New("request was rejected by the node")
 )
 
 type BeaconClient struct {
 	BaseURLStr string
 	HTTPClient *http.Client
 	BaseURL    *url.URL
+	// ValidatorChunkSize is the max number of validator ids sent in a single request
+	ValidatorChunkSize int
+	// MaxConcurrentRequests limits the number of chunks fetched at the same time
+	MaxConcurrentRequests int
+
+	// validatorsPostUnsupported is shared with the copies made by WithContext
+	validatorsPostUnsupported *atomic.Bool
+	// ctx is the context of the requests, see WithContext
+	ctx context.Context
+}
+
+// WithContext returns a copy of the client sending its requests with ctx,
+// so they are canceled along with it and traced as part of its span.
+func (c *BeaconClient) WithContext(ctx context.Context) *BeaconClient {
+	client := *c
+	client.ctx = ctx
+	return &client
+}
+
+func (c *BeaconClient) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
 }
 
 func (c *BeaconClient) FetchBlockResponse(slotno int64) (*BlockResponse, error) {
 	newURL := *c.BaseURL
 	newURL.Path = path.Join(newURL.Path, fmt.Sprintf(constBlockPath, slotno))
 	currentURL := newURL.String()
-	resp, err := c.HTTPClient.Get(currentURL)
+	resp, err := c.get("FetchBlockResponse", currentURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch block response: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
 	}
@@ -55,13 +111,16 @@ func (c *BeaconClient) FetchBlockResponse(slotno int64) (*BlockResponse, error)
 
 func (c *BeaconClient) FetchBlockRewardsResponse(slotno int64) (*BLockRewardsResponse, error) {
 	newURL := *c.BaseURL
-	newURL.Path = path.Join(newURL.Path, fmt.Sprintf(constBlockPath, slotno))
+	newURL.Path = path.Join(newURL.Path, fmt.Sprintf(constBlockRewards, slotno))
 	currentURL := newURL.String()
-	resp, err := c.HTTPClient.Get(currentURL)
+	resp, err := c.get("FetchBlockRewardsResponse", currentURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch block response: %w", err)
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
 	}
@@ -75,7 +134,11 @@ func (c *BeaconClient) FetchBlockRewardsResponse(slotno int64) (*BLockRewardsRes
 func (c *BeaconClient) FetchAttestationRewardsEstimate(slotno, validatorIndex int64) (int64, error) {
 	epochno := slotno / 32
 	currentURL := fmt.Sprintf(constRewardsHistory, validatorIndex, epochno)
-	resp, err := c.HTTPClient.Get(currentURL)
+	req, err := http.NewRequestWithContext(c.context(), http.MethodGet, currentURL, http.NoBody)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	resp, err := c.doUpstream(metrics.UpstreamBeaconcha, "FetchAttestationRewardsEstimate", req)
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch block response: %w", err)
 	}
@@ -98,7 +161,7 @@ func (c *BeaconClient) FetchSyncDuties(slotno int64) (*SyncDutiesResponse, error
 	newURL.Path = path.Join(newURL.Path, fmt.Sprintf(constSyncDutiesPath, slotno))
 	currentURL := newURL.String()
 
-	resp, err := http.Get(currentURL)
+	resp, err := c.get("FetchSyncDuties", currentURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch sync duties response: %w", err)
 	}
@@ -113,7 +176,134 @@ func (c *BeaconClient) FetchSyncDuties(slotno int64) (*SyncDutiesResponse, error
 	return &syncDutiesResp, nil
 }
 
+// PublicKeysByValidatorIDs resolves validator records for the given indices at the given slot.
+// The indices are split into chunks of ValidatorChunkSize which are fetched concurrently,
+// at most MaxConcurrentRequests at a time. The combined result holds one record per
+// distinct index, in the order the indices were first passed in; indices unknown to
+// the node are left out, just like the node does.
 func (c *BeaconClient) PublicKeysByValidatorIDs(validatorIDs []int64, slotno int64) (*ValidatorResponse, error) {
+	uniqueIDs := make([]int64, 0, len(validatorIDs))
+	seen := make(map[int64]struct{}, len(validatorIDs))
+	for _, id := range validatorIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		uniqueIDs = append(uniqueIDs, id)
+	}
+	chunkSize := c.ValidatorChunkSize
+	if chunkSize <= 0 {
+		chunkSize = constValidatorChunkSize
+	}
+	chunks := make([][]int64, 0, len(uniqueIDs)/chunkSize+1)
+	for start := 0; start < len(uniqueIDs); start += chunkSize {
+		chunks = append(chunks, uniqueIDs[start:min(start+chunkSize, len(uniqueIDs))])
+	}
+
+	responses := make([]*ValidatorResponse, len(chunks))
+	// the first chunk failing cancels the others
+	group, ctx := errgroup.WithContext(c.context())
+	if c.MaxConcurrentRequests > 0 {
+		group.SetLimit(c.MaxConcurrentRequests)
+	}
+	for i, chunk := range chunks {
+		group.Go(func() error {
+			resp, err := c.fetchValidators(ctx, chunk, slotno)
+			if err != nil {
+				return err
+			}
+			responses[i] = resp
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	result := &ValidatorResponse{Finalized: true, Data: make([]ValidatorData, 0, len(uniqueIDs))}
+	byIndex := make(map[string]ValidatorData, len(uniqueIDs))
+	for _, resp := range responses {
+		result.ExecutionOptimistic = result.ExecutionOptimistic || resp.ExecutionOptimistic
+		result.Finalized = result.Finalized && resp.Finalized
+		for _, validator := range resp.Data {
+			byIndex[validator.Index] = validator
+		}
+	}
+	for _, id := range uniqueIDs {
+		if validator, ok := byIndex[strconv.FormatInt(id, 10)]; ok {
+			result.Data = append(result.Data, validator)
+		}
+	}
+	return result, nil
+}
+
+// fetchValidators fetches a single chunk of validators. The POST form of the endpoint
+// is preferred since it has no URL length limit; nodes that do not implement it yet
+// are remembered and queried with GET from then on.
+func (c *BeaconClient) fetchValidators(ctx context.Context, validatorIDs []int64, slotno int64) (*ValidatorResponse, error) {
+	if c.validatorsPostUnsupported.Load() {
+		return c.getValidators(ctx, validatorIDs, slotno)
+	}
+	resp, err := c.postValidators(ctx, validatorIDs, slotno)
+	switch {
+	case errors.Is(err, errMethodUnsupported):
+		c.validatorsPostUnsupported.Store(true)
+		return c.getValidators(ctx, validatorIDs, slotno)
+	case errors.Is(err, errPostRejected):
+		// an unknown state is rejected too, only a GET answering tells the POST form is missing
+		resp, err = c.getValidators(ctx, validatorIDs, slotno)
+		if err == nil {
+			c.validatorsPostUnsupported.Store(true)
+		}
+		return resp, err
+	}
+	return resp, err
+}
+
+func (c *BeaconClient) postValidators(ctx context.Context, validatorIDs []int64, slotno int64) (*ValidatorResponse, error) {
+	newURL := *c.BaseURL
+	newURL.Path = path.Join(newURL.Path, fmt.Sprintf(constValidatorPath, slotno))
+	currentURL := newURL.String()
+
+	ids := make([]string, 0, len(validatorIDs))
+	for _, id := range validatorIDs {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	payload, err := json.Marshal(struct {
+		IDs []string `json:"ids"`
+	}{IDs: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode validator request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, currentURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do("postValidators", req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch validator response: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented, http.StatusUnsupportedMediaType:
+		return nil, errMethodUnsupported
+	case http.StatusNotFound, http.StatusBadRequest:
+		return nil, errPostRejected
+	default:
+		return nil, fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
+	}
+	var validatorResp ValidatorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&validatorResp); err != nil {
+		return nil, fmt.Errorf("failed to decode validator response: %w", err)
+	}
+	return &validatorResp, nil
+}
+
+func (c *BeaconClient) getValidators(ctx context.Context, validatorIDs []int64, slotno int64) (*ValidatorResponse, error) {
 	newURL := *c.BaseURL
 	var builder strings.Builder
 
@@ -129,7 +319,7 @@ func (c *BeaconClient) PublicKeysByValidatorIDs(validatorIDs []int64, slotno int
 	params.Add("id", builder.String())
 	newURL.RawQuery = params.Encode()
 	currentURL := newURL.String()
-	resp, err := c.HTTPClient.Get(currentURL)
+	resp, err := c.WithContext(ctx).get("getValidators", currentURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch validator response: %w", err)
 	}
@@ -144,11 +334,199 @@ func (c *BeaconClient) PublicKeysByValidatorIDs(validatorIDs []int64, slotno int
 	return &validatorResp, nil
 }
 
+// FetchValidator fetches a single validator by index or 0x pubkey.
+// ErrNotFound is returned when the node knows neither the state nor the validator.
+func (c *BeaconClient) FetchValidator(stateID, validatorID string) (*SingleValidatorResponse, error) {
+	newURL := *c.BaseURL
+	newURL.Path = path.Join(newURL.Path, fmt.Sprintf(constSingleValidator, stateID, validatorID))
+	currentURL := newURL.String()
+	resp, err := c.get("FetchValidator", currentURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch validator response: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
+	}
+	var validatorResp SingleValidatorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&validatorResp); err != nil {
+		return nil, fmt.Errorf("failed to decode validator response: %w", err)
+	}
+	return &validatorResp, nil
+}
+
+// FetchBlockHeader fetches the header of the block at the given slot,
+// ErrNotFound means there is no canonical block in that slot.
+func (c *BeaconClient) FetchBlockHeader(slotno int64) (*BlockHeaderResponse, error) {
+	newURL := *c.BaseURL
+	newURL.Path = path.Join(newURL.Path, fmt.Sprintf(constHeaderPath, slotno))
+	currentURL := newURL.String()
+	resp, err := c.get("FetchBlockHeader", currentURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block header: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
+	}
+	var headerResp BlockHeaderResponse
+	if err := json.NewDecoder(resp.Body).Decode(&headerResp); err != nil {
+		return nil, fmt.Errorf("failed to decode block header: %w", err)
+	}
+	return &headerResp, nil
+}
+
+func (c *BeaconClient) FetchProposerDuties(epoch int64) (*ProposerDutiesResponse, error) {
+	newURL := *c.BaseURL
+	newURL.Path = path.Join(newURL.Path, fmt.Sprintf(constProposerDutiesPath, epoch))
+	currentURL := newURL.String()
+	resp, err := c.get("FetchProposerDuties", currentURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch proposer duties: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("epoch %d: %w", epoch, ErrProposersUnavailable)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
+	}
+	var dutiesResp ProposerDutiesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&dutiesResp); err != nil {
+		return nil, fmt.Errorf("failed to decode proposer duties: %w", err)
+	}
+	return &dutiesResp, nil
+}
+
+// FetchBlobSidecars fetches the blob sidecars of the block of the slot. The nodes prune the sidecars
+// older than about 18 days, the response of a pruned block has none.
+func (c *BeaconClient) FetchBlobSidecars(slotno int64) (*BlobSidecarsResponse, error) {
+	newURL := *c.BaseURL
+	newURL.Path = path.Join(newURL.Path, fmt.Sprintf(constBlobSidecarsPath, slotno))
+	currentURL := newURL.String()
+	resp, err := c.get("FetchBlobSidecars", currentURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blob sidecars: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
+	}
+	var sidecarsResp BlobSidecarsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&sidecarsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode blob sidecars: %w", err)
+	}
+	return &sidecarsResp, nil
+}
+
+// FetchAllValidators streams every validator of the state at the given slot into fn,
+// without holding the whole (rather huge) response in memory.
+func (c *BeaconClient) FetchAllValidators(slotno int64, fn func(*ValidatorData) error) error {
+	newURL := *c.BaseURL
+	newURL.Path = path.Join(newURL.Path, fmt.Sprintf(constValidatorPath, slotno))
+	currentURL := newURL.String()
+	resp, err := c.get("FetchAllValidators", currentURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch validator response: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("failed to decode validator response: %w", err)
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("failed to decode validator response: %w", err)
+		}
+		if key != "data" {
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return fmt.Errorf("failed to decode validator response: %w", err)
+			}
+			continue
+		}
+		if _, err := decoder.Token(); err != nil {
+			return fmt.Errorf("failed to decode validator response: %w", err)
+		}
+		for decoder.More() {
+			var validator ValidatorData
+			if err := decoder.Decode(&validator); err != nil {
+				return fmt.Errorf("failed to decode validator response: %w", err)
+			}
+			if err := fn(&validator); err != nil {
+				return err
+			}
+		}
+		if _, err := decoder.Token(); err != nil {
+			return fmt.Errorf("failed to decode validator response: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *BeaconClient) FetchFinalityCheckpoints(stateID string) (*FinalityCheckpointsResponse, error) {
+	newURL := *c.BaseURL
+	newURL.Path = path.Join(newURL.Path, fmt.Sprintf(constFinalityPath, stateID))
+	currentURL := newURL.String()
+	resp, err := c.get("FetchFinalityCheckpoints", currentURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch finality checkpoints: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
+	}
+	var checkpointsResp FinalityCheckpointsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&checkpointsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode finality checkpoints: %w", err)
+	}
+	return &checkpointsResp, nil
+}
+
+// FetchSyncing returns the head slot and sync state of the node.
+func (c *BeaconClient) FetchSyncing() (*SyncingResponse, error) {
+	newURL := *c.BaseURL
+	newURL.Path = path.Join(newURL.Path, constSyncingPath)
+	currentURL := newURL.String()
+	resp, err := c.get("FetchSyncing", currentURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sync status: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
+	}
+	var syncingResp SyncingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&syncingResp); err != nil {
+		return nil, fmt.Errorf("failed to decode sync status: %w", err)
+	}
+	return &syncingResp, nil
+}
+
 func (c *BeaconClient) MapSlotToTimestamp(slotNo int64) time.Time {
 	offset := time.Duration(EthereumSlotDuration*slotNo) * time.Second
 	return EthereumMainnetGenesisTime.Add(offset)
 }
 
+// MapTimestampToSlot returns the slot in progress at the given time.
+func (c *BeaconClient) MapTimestampToSlot(t time.Time) int64 {
+	return int64(t.Sub(EthereumMainnetGenesisTime) / (EthereumSlotDuration * time.Second))
+}
+
 func (c *BeaconClient) FetchSyncDutiesReward(slotno, valIndex int64) (*RewardsResp, error) {
 	newURL := *c.BaseURL
 	newURL.Path = path.Join(newURL.Path, fmt.Sprintf(constSyncDutiesRewards, slotno))
@@ -156,14 +534,14 @@ func (c *BeaconClient) FetchSyncDutiesReward(slotno, valIndex int64) (*RewardsRe
 
 	payload := []byte(fmt.Sprintf(`["%v"]`, valIndex))
 
-	req, err := http.NewRequest("POST", currentURL, bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(c.context(), http.MethodPost, currentURL, bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 	req.Header.Set("accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do("FetchSyncDutiesReward", req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch sync duties response: %w", err)
 	}
@@ -186,14 +564,14 @@ func (c *BeaconClient) FetchAttestionsReward(slotno, valIndex int64) (*Attestati
 
 	payload := []byte(fmt.Sprintf(`["%v"]`, valIndex))
 
-	req, err := http.NewRequest("POST", currentURL, bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(c.context(), http.MethodPost, currentURL, bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 	req.Header.Set("accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do("FetchAttestionsReward", req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch sync duties response: %w", err)
 	}
@@ -216,6 +594,32 @@ func (c *BeaconClient) FetchAttestionsReward(slotno, valIndex int64) (*Attestati
 //	// implement me
 //}
 
+// get and do send the request with HTTPClient and record the call under the name of the client method.
+func (c *BeaconClient) get(method, currentURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(c.context(), http.MethodGet, currentURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	return c.do(method, req)
+}
+
+func (c *BeaconClient) do(method string, req *http.Request) (*http.Response, error) {
+	return c.doUpstream(metrics.UpstreamBeacon, method, req)
+}
+
+func (c *BeaconClient) doUpstream(upstream, method string, req *http.Request) (*http.Response, error) {
+	ctx, span := tracing.StartUpstream(req.Context(), upstream, method, req)
+	start := time.Now()
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	metrics.ObserveUpstream(upstream, method, start, status, err)
+	tracing.End(span, status, err)
+	return resp, err
+}
+
 func NewBeaconClient(baseURL string, httpClient *http.Client) (*BeaconClient, error) {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
@@ -225,8 +629,12 @@ func NewBeaconClient(baseURL string, httpClient *http.Client) (*BeaconClient, er
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
 	return &BeaconClient{
-		BaseURL:    base,
-		HTTPClient: httpClient,
+		BaseURL:               base,
+		HTTPClient:            httpClient,
+		ValidatorChunkSize:    constValidatorChunkSize,
+		MaxConcurrentRequests: constMaxConcurrentRequests,
+		// shared by the copies made by WithContext
+		validatorsPostUnsupported: new(atomic.Bool),
 	}, nil
 }
 
@@ -1,7 +1,15 @@
 package beaconadapter
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/spf13/viper"
@@ -97,7 +105,7 @@ func TestBeaconClientSyncDuties(t *testing.T) {
 			continue
 		}
 		require.NoError(t, err)
-		require.Equal(t, 512, len(resp.Data))
+		require.Equal(t, 512, len(resp.Data.Validators))
 	}
 
 }
@@ -154,3 +162,152 @@ func TestAttestationDutiesRewards(t *testing.T) {
 	_, err = client.FetchAttestionsReward(6499529, 206722)
 	require.NoError(t, err)
 }
+
+// validatorsStandIn serves the validators endpoint from a fixed set of indices.
+// Like a real node it answers sorted by index and silently drops unknown ids.
+// Only answered requests are counted.
+func validatorsStandIn(t *testing.T, known map[int64]bool, postSupported bool, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ids []string
+		switch r.Method {
+		case http.MethodPost:
+			if !postSupported {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			var body struct {
+				IDs []string `json:"ids"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			ids = body.IDs
+		default:
+			ids = strings.Split(r.URL.Query().Get("id"), ",")
+		}
+		indices := make([]int64, 0, len(ids))
+		for _, id := range ids {
+			index, err := strconv.ParseInt(id, 10, 64)
+			require.NoError(t, err)
+			if known[index] {
+				indices = append(indices, index)
+			}
+		}
+		requests.Add(1)
+		sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+		resp := ValidatorResponse{Finalized: true}
+		for _, index := range indices {
+			var v ValidatorData
+			v.Index = strconv.FormatInt(index, 10)
+			v.Validator.Pubkey = fmt.Sprintf("0x%04d", index)
+			resp.Data = append(resp.Data, v)
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+}
+
+func TestPublicKeysByValidatorIDsChunked(t *testing.T) {
+	known := map[int64]bool{}
+	for i := int64(0); i < 50; i++ {
+		known[i] = true
+	}
+	indices := []int64{42, 7, 7, 13, 99, 0, 31, 42, 5}
+	expected := []string{"0x0042", "0x0007", "0x0013", "0x0000", "0x0031", "0x0005"}
+
+	for _, postSupported := range []bool{true, false} {
+		t.Run(fmt.Sprintf("post supported: %v", postSupported), func(t *testing.T) {
+			var requests atomic.Int32
+			server := validatorsStandIn(t, known, postSupported, &requests)
+			defer server.Close()
+			client, err := NewBeaconClient(server.URL, nil)
+			require.NoError(t, err)
+			client.ValidatorChunkSize = 2
+
+			resp, err := client.PublicKeysByValidatorIDs(indices, 100)
+			require.NoError(t, err)
+			pubkeys := make([]string, 0, len(resp.Data))
+			for _, validator := range resp.Data {
+				pubkeys = append(pubkeys, validator.Validator.Pubkey)
+			}
+			require.Equal(t, expected, pubkeys)
+			require.True(t, resp.Finalized)
+			// 7 distinct ids in chunks of 2
+			require.Equal(t, int32(4), requests.Load())
+		})
+	}
+}
+
+func TestValidatorsPostUnsupportedRemembered(t *testing.T) {
+	// nodes without the POST form answer 405, or 404 as for an unknown route
+	for _, status := range []int{http.StatusMethodNotAllowed, http.StatusNotFound} {
+		t.Run(strconv.Itoa(status), func(t *testing.T) {
+			var posts, gets atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					posts.Add(1)
+					w.WriteHeader(status)
+					return
+				}
+				gets.Add(1)
+				fmt.Fprint(w, `{"finalized":true,"data":[{"index":"7","validator":{"pubkey":"0x0007"}}]}`)
+			}))
+			defer server.Close()
+
+			// the handlers copy a shared client for every request
+			shared, err := NewBeaconClient(server.URL, nil)
+			require.NoError(t, err)
+			_, err = shared.WithContext(context.Background()).PublicKeysByValidatorIDs([]int64{7}, 100)
+			require.NoError(t, err)
+			require.Equal(t, int32(1), posts.Load())
+
+			resp, err := shared.WithContext(context.Background()).PublicKeysByValidatorIDs([]int64{7}, 100)
+			require.NoError(t, err)
+			require.Len(t, resp.Data, 1)
+			// the second request went straight to GET
+			require.Equal(t, int32(1), posts.Load())
+			require.Equal(t, int32(2), gets.Load())
+
+			// another client doesn't know
+			other, err := NewBeaconClient(server.URL, nil)
+			require.NoError(t, err)
+			_, err = other.PublicKeysByValidatorIDs([]int64{7}, 100)
+			require.NoError(t, err)
+			require.Equal(t, int32(2), posts.Load())
+		})
+	}
+
+	t.Run("unknown state", func(t *testing.T) {
+		var posts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				posts.Add(1)
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		client, err := NewBeaconClient(server.URL, nil)
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			_, err = client.PublicKeysByValidatorIDs([]int64{7}, 100)
+			require.Error(t, err)
+		}
+		// the GET failing too, the POST form is still used
+		require.Equal(t, int32(2), posts.Load())
+	})
+}
+
+func TestPublicKeysByValidatorIDsCanceled(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	client, err := NewBeaconClient(server.URL, nil)
+	require.NoError(t, err)
+	client.ValidatorChunkSize = 1
+	client.MaxConcurrentRequests = 1
+	// the first chunk failing, the others are never sent
+	_, err = client.PublicKeysByValidatorIDs([]int64{1, 2, 3, 4}, 100)
+	require.Error(t, err)
+	require.Equal(t, int32(1), requests.Load())
+}
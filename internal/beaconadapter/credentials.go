@@ -0,0 +1,23 @@
+package beaconadapter
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ParseWithdrawalCredentials returns the credentials prefix and, for execution
+// credentials (0x01 and compounding 0x02), the withdrawal address they point to.
+// BLS credentials (0x00) have no address yet.
+func ParseWithdrawalCredentials(credentials string) (credentialsType, address string, err error) {
+	raw := common.FromHex(credentials)
+	if len(raw) != common.HashLength {
+		return "", "", fmt.Errorf("unexpected withdrawal credentials %q", credentials)
+	}
+	credentialsType = fmt.Sprintf("0x%02x", raw[0])
+	switch raw[0] {
+	case 0x01, 0x02:
+		address = common.BytesToAddress(raw[common.HashLength-common.AddressLength:]).Hex()
+	}
+	return credentialsType, address, nil
+}
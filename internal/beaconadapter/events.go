@@ -0,0 +1,87 @@
+package beaconadapter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+const (
+	constEventsPath = "/eth/v1/events"
+
+	EventHead                = "head"
+	EventBlock               = "block"
+	EventFinalizedCheckpoint = "finalized_checkpoint"
+	EventChainReorg          = "chain_reorg"
+
+	// constMaxEventSize is way above any event the beacon node emits today
+	constMaxEventSize = 1 << 20
+)
+
+// Event is a single server-sent event from the beacon node.
+type Event struct {
+	Topic string
+	Data  json.RawMessage
+}
+
+// SubscribeEvents streams the events of the given topics into fn until the context is
+// canceled, the stream ends or fn fails. It never reconnects, that's up to the caller.
+func (c *BeaconClient) SubscribeEvents(ctx context.Context, topics []string, fn func(Event) error) error {
+	newURL := *c.BaseURL
+	newURL.Path = path.Join(newURL.Path, constEventsPath)
+	params := url.Values{}
+	params.Add("topics", strings.Join(topics, ","))
+	newURL.RawQuery = params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, newURL.String(), http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("accept", "text/event-stream")
+
+	resp, err := c.do("SubscribeEvents", req)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to events: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), constMaxEventSize)
+	var event Event
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// a blank line dispatches the event
+			if event.Topic != "" && data.Len() > 0 {
+				event.Data = json.RawMessage(data.String())
+				if err := fn(event); err != nil {
+					return err
+				}
+			}
+			event = Event{}
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// comment, nodes send them as keep-alives
+		case strings.HasPrefix(line, "event:"):
+			event.Topic = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to read events: %w", err)
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,38 @@
+package beaconadapter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/eth/v1/events", r.URL.Path)
+		require.Equal(t, "head,finalized_checkpoint", r.URL.Query().Get("topics"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "event: head\ndata: {\"slot\":\"10\",\"block\":\"0xaa\"}\n\n")
+		fmt.Fprint(w, "event:finalized_checkpoint\ndata:{\"epoch\":\n")
+		fmt.Fprint(w, "data:\"2\"}\n\n")
+	}))
+	defer server.Close()
+	client, err := NewBeaconClient(server.URL, nil)
+	require.NoError(t, err)
+
+	var events []Event
+	err = client.SubscribeEvents(context.Background(), []string{EventHead, EventFinalizedCheckpoint}, func(event Event) error {
+		events = append(events, event)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, EventHead, events[0].Topic)
+	require.JSONEq(t, `{"slot":"10","block":"0xaa"}`, string(events[0].Data))
+	require.Equal(t, EventFinalizedCheckpoint, events[1].Topic)
+	require.JSONEq(t, `{"epoch":"2"}`, string(events[1].Data))
+}
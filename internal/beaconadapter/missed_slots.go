@@ -0,0 +1,104 @@
+package beaconadapter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// MissedSlot is a slot without a canonical block.
+// Finalized is false while the slot is not covered by the finalized checkpoint yet:
+// the block may still show up late or the slot may get reorged.
+type MissedSlot struct {
+	Slot          int64
+	ProposerIndex int64
+	Finalized     bool
+}
+
+// FinalizedSlot returns the first slot of the current finalized epoch.
+func (c *BeaconClient) FinalizedSlot() (int64, error) {
+	checkpoints, err := c.FetchFinalityCheckpoints("head")
+	if err != nil {
+		return 0, err
+	}
+	finalizedEpoch, err := strconv.ParseInt(checkpoints.Data.Finalized.Epoch, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse finalized epoch: %w", err)
+	}
+	return finalizedEpoch * EthereumSlotsPerEpoch, nil
+}
+
+// MissedSlots lists the slots in [from, to] without a block, along with the validator
+// that was scheduled to propose in each of them. Any error other than a 404 for
+// a block fails the whole lookup, so a flaky node is never reported as missed slots.
+// The proposers come from the duties, a missed slot has no header to read one from:
+// the lookup fails with ErrProposersUnavailable when the node has no duties for the epoch.
+func (c *BeaconClient) MissedSlots(from, to int64) ([]MissedSlot, error) {
+	finalizedSlot, err := c.FinalizedSlot()
+	if err != nil {
+		return nil, err
+	}
+
+	missed := make([]bool, to-from+1)
+	group := new(errgroup.Group)
+	if c.MaxConcurrentRequests > 0 {
+		group.SetLimit(c.MaxConcurrentRequests)
+	}
+	for slot := from; slot <= to; slot++ {
+		group.Go(func() error {
+			_, err := c.FetchBlockHeader(slot)
+			if errors.Is(err, ErrNotFound) {
+				missed[slot-from] = true
+				return nil
+			}
+			return err
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	result := make([]MissedSlot, 0)
+	proposers := make(map[int64]int64)
+	for slot := from; slot <= to; slot++ {
+		if !missed[slot-from] {
+			continue
+		}
+		if _, ok := proposers[slot]; !ok {
+			if err := c.loadProposers(slot/EthereumSlotsPerEpoch, proposers); err != nil {
+				return nil, err
+			}
+		}
+		proposerIndex, ok := proposers[slot]
+		if !ok {
+			return nil, fmt.Errorf("no proposer scheduled for slot %d", slot)
+		}
+		result = append(result, MissedSlot{
+			Slot:          slot,
+			ProposerIndex: proposerIndex,
+			Finalized:     slot <= finalizedSlot,
+		})
+	}
+	return result, nil
+}
+
+func (c *BeaconClient) loadProposers(epoch int64, proposers map[int64]int64) error {
+	duties, err := c.FetchProposerDuties(epoch)
+	if err != nil {
+		return err
+	}
+	for _, duty := range duties.Data {
+		slot, err := strconv.ParseInt(duty.Slot, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse proposer duty slot: %w", err)
+		}
+		index, err := strconv.ParseInt(duty.ValidatorIndex, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse proposer index: %w", err)
+		}
+		proposers[slot] = index
+	}
+	return nil
+}
@@ -0,0 +1,330 @@
+package beaconadapter
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	constPubkeyLength       = 48
+	constRegistryFileMagic  = "EVR1"
+	constRegistryBatchCount = 4
+)
+
+var (
+	ErrRegistryCorrupted = errors.New("validator registry file is corrupted")
+)
+
+type blsPubkey [constPubkeyLength]byte
+
+// registryHeader follows the magic bytes in the registry file, the pubkeys follow it.
+type registryHeader struct {
+	FinalizedEpoch int64
+	Count          int64
+}
+
+// Registry maps validator indices to pubkeys and back.
+// A validator's pubkey never changes once it got its index, so the registry is
+// bulk-loaded once from the finalized state and then only extended with new deposits.
+// It is persisted to disk, so a restarted server is warm right away.
+type Registry struct {
+	client *BeaconClient
+	file   string
+
+	mu             sync.RWMutex
+	pubkeys        []blsPubkey
+	indices        map[blsPubkey]int64
+	finalizedEpoch int64
+}
+
+func NewRegistry(client *BeaconClient, file string) *Registry {
+	return &Registry{
+		client:  client,
+		file:    file,
+		indices: make(map[blsPubkey]int64),
+	}
+}
+
+// Len returns the number of known validators.
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.pubkeys)
+}
+
+// FinalizedEpoch returns the finalized epoch the registry was last synced to.
+func (r *Registry) FinalizedEpoch() int64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.finalizedEpoch
+}
+
+// Pubkey returns the 0x-prefixed pubkey of the validator with the given index.
+func (r *Registry) Pubkey(index int64) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if index < 0 || index >= int64(len(r.pubkeys)) {
+		return "", false
+	}
+	return "0x" + hex.EncodeToString(r.pubkeys[index][:]), true
+}
+
+// Pubkeys resolves all indices or none: ok is false if any index is unknown.
+func (r *Registry) Pubkeys(indices []int64) ([]string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]string, 0, len(indices))
+	for _, index := range indices {
+		if index < 0 || index >= int64(len(r.pubkeys)) {
+			return nil, false
+		}
+		result = append(result, "0x"+hex.EncodeToString(r.pubkeys[index][:]))
+	}
+	return result, true
+}
+
+// Index returns the index of the validator with the given 0x-prefixed pubkey.
+func (r *Registry) Index(pubkey string) (int64, bool) {
+	key, err := parsePubkey(pubkey)
+	if err != nil {
+		return 0, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	index, ok := r.indices[key]
+	return index, ok
+}
+
+// Refresh brings the registry up to the current finalized checkpoint.
+// An empty registry is bulk-loaded from the finalized state, otherwise only
+// the validators deposited since the last refresh are fetched.
+func (r *Registry) Refresh() error {
+	checkpoints, err := r.client.FetchFinalityCheckpoints("head")
+	if err != nil {
+		return err
+	}
+	finalizedEpoch, err := strconv.ParseInt(checkpoints.Data.Finalized.Epoch, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse finalized epoch: %w", err)
+	}
+	if finalizedEpoch <= r.FinalizedEpoch() && r.Len() > 0 {
+		return nil
+	}
+	finalizedSlot := finalizedEpoch * EthereumSlotsPerEpoch
+
+	var added []blsPubkey
+	if r.Len() == 0 {
+		added, err = r.fetchAll(finalizedSlot)
+	} else {
+		added, err = r.fetchNew(finalizedSlot)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	for _, pubkey := range added {
+		r.indices[pubkey] = int64(len(r.pubkeys))
+		r.pubkeys = append(r.pubkeys, pubkey)
+	}
+	r.finalizedEpoch = finalizedEpoch
+	r.mu.Unlock()
+	logrus.Infof("validator registry synced to epoch %v: %v validators, %v new", finalizedEpoch, r.Len(), len(added))
+	return r.Save()
+}
+
+func (r *Registry) fetchAll(slotno int64) ([]blsPubkey, error) {
+	pubkeys := make([]blsPubkey, 0)
+	err := r.client.FetchAllValidators(slotno, func(validator *ValidatorData) error {
+		index, err := strconv.ParseInt(validator.Index, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse validator index: %w", err)
+		}
+		if index != int64(len(pubkeys)) {
+			return fmt.Errorf("validator %d is out of order", index)
+		}
+		pubkey, err := parsePubkey(validator.Validator.Pubkey)
+		if err != nil {
+			return err
+		}
+		pubkeys = append(pubkeys, pubkey)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pubkeys, nil
+}
+
+// fetchNew asks for the indices right after the last known one, batch by batch,
+// until the node returns fewer validators than were asked for.
+func (r *Registry) fetchNew(slotno int64) ([]blsPubkey, error) {
+	batchSize := r.client.ValidatorChunkSize * constRegistryBatchCount
+	if batchSize <= 0 {
+		batchSize = constValidatorChunkSize * constRegistryBatchCount
+	}
+	next := int64(r.Len())
+	pubkeys := make([]blsPubkey, 0)
+	for {
+		ids := make([]int64, 0, batchSize)
+		for i := 0; i < batchSize; i++ {
+			ids = append(ids, next+int64(i))
+		}
+		resp, err := r.client.PublicKeysByValidatorIDs(ids, slotno)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Data {
+			if resp.Data[i].Index != strconv.FormatInt(next, 10) {
+				return nil, fmt.Errorf("validator %s is out of order", resp.Data[i].Index)
+			}
+			pubkey, err := parsePubkey(resp.Data[i].Validator.Pubkey)
+			if err != nil {
+				return nil, err
+			}
+			pubkeys = append(pubkeys, pubkey)
+			next++
+		}
+		if len(resp.Data) < batchSize {
+			return pubkeys, nil
+		}
+	}
+}
+
+// Run refreshes the registry every interval until the context is canceled.
+func (r *Registry) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Refresh(); err != nil {
+			logrus.WithError(err).Error("failed to refresh the validator registry")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Load reads the registry from disk. A missing file is not an error,
+// the registry just stays empty until the first Refresh.
+func (r *Registry) Load() error {
+	if r.file == "" {
+		return nil
+	}
+	f, err := os.Open(r.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open validator registry: %w", err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	magic := make([]byte, len(constRegistryFileMagic))
+	if _, err := io.ReadFull(reader, magic); err != nil || string(magic) != constRegistryFileMagic {
+		return ErrRegistryCorrupted
+	}
+	var header registryHeader
+	if err := binary.Read(reader, binary.LittleEndian, &header); err != nil || header.Count < 0 {
+		return ErrRegistryCorrupted
+	}
+	// the count is checked against the file before allocating, a damaged header could ask for anything
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat validator registry: %w", err)
+	}
+	remaining := info.Size() - int64(len(constRegistryFileMagic)) - int64(binary.Size(header))
+	if remaining%int64(len(blsPubkey{})) != 0 || header.Count != remaining/int64(len(blsPubkey{})) {
+		return ErrRegistryCorrupted
+	}
+	pubkeys := make([]blsPubkey, header.Count)
+	indices := make(map[blsPubkey]int64, header.Count)
+	for i := range pubkeys {
+		if _, err := io.ReadFull(reader, pubkeys[i][:]); err != nil {
+			return ErrRegistryCorrupted
+		}
+		indices[pubkeys[i]] = int64(i)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pubkeys = pubkeys
+	r.indices = indices
+	r.finalizedEpoch = header.FinalizedEpoch
+	return nil
+}
+
+// Save writes the registry to disk, replacing the previous file atomically.
+func (r *Registry) Save() error {
+	if r.file == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(r.file), 0o755); err != nil {
+		return fmt.Errorf("failed to create registry directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(r.file), filepath.Base(r.file)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create registry file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	r.mu.RLock()
+	writer := bufio.NewWriter(tmp)
+	err = r.write(writer)
+	r.mu.RUnlock()
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write validator registry: %w", err)
+	}
+	return os.Rename(tmp.Name(), r.file)
+}
+
+func (r *Registry) write(w io.Writer) error {
+	if _, err := io.WriteString(w, constRegistryFileMagic); err != nil {
+		return err
+	}
+	header := registryHeader{FinalizedEpoch: r.finalizedEpoch, Count: int64(len(r.pubkeys))}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	for i := range r.pubkeys {
+		if _, err := w.Write(r.pubkeys[i][:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parsePubkey(pubkey string) (blsPubkey, error) {
+	var key blsPubkey
+	raw, err := hex.DecodeString(strings.TrimPrefix(pubkey, "0x"))
+	if err != nil {
+		return key, fmt.Errorf("failed to decode pubkey %s: %w", pubkey, err)
+	}
+	if len(raw) != constPubkeyLength {
+		return key, fmt.Errorf("pubkey %s has unexpected length %d", pubkey, len(raw))
+	}
+	copy(key[:], raw)
+	return key, nil
+}
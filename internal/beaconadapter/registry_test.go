@@ -0,0 +1,126 @@
+package beaconadapter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testPubkey(index int64) string {
+	return fmt.Sprintf("0x%096x", index+1)
+}
+
+// registryStandIn is a beacon node with a growing validator set.
+type registryStandIn struct {
+	mu             sync.Mutex
+	count          int64
+	finalizedEpoch int64
+}
+
+func (s *registryStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if strings.HasSuffix(r.URL.Path, "/finality_checkpoints") {
+		var resp FinalityCheckpointsResponse
+		resp.Data.Finalized.Epoch = strconv.FormatInt(s.finalizedEpoch, 10)
+		_ = json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var ids []string
+	if r.Method == http.MethodPost {
+		var body struct {
+			IDs []string `json:"ids"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		ids = body.IDs
+	} else if id := r.URL.Query().Get("id"); id != "" {
+		ids = strings.Split(id, ",")
+	} else {
+		for i := int64(0); i < s.count; i++ {
+			ids = append(ids, strconv.FormatInt(i, 10))
+		}
+	}
+	resp := ValidatorResponse{Finalized: true, Data: []ValidatorData{}}
+	for _, id := range ids {
+		index, _ := strconv.ParseInt(id, 10, 64)
+		if index >= s.count {
+			continue
+		}
+		var v ValidatorData
+		v.Index = id
+		v.Validator.Pubkey = testPubkey(index)
+		resp.Data = append(resp.Data, v)
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func TestRegistry(t *testing.T) {
+	node := &registryStandIn{count: 10, finalizedEpoch: 3}
+	server := httptest.NewServer(node)
+	defer server.Close()
+	client, err := NewBeaconClient(server.URL, nil)
+	require.NoError(t, err)
+	client.ValidatorChunkSize = 3
+	file := filepath.Join(t.TempDir(), "registry.bin")
+
+	registry := NewRegistry(client, file)
+	require.NoError(t, registry.Load())
+	require.Equal(t, 0, registry.Len())
+
+	t.Run("bulk load", func(t *testing.T) {
+		require.NoError(t, registry.Refresh())
+		require.Equal(t, 10, registry.Len())
+		require.Equal(t, int64(3), registry.FinalizedEpoch())
+		pubkey, ok := registry.Pubkey(7)
+		require.True(t, ok)
+		require.Equal(t, testPubkey(7), pubkey)
+		index, ok := registry.Index(testPubkey(4))
+		require.True(t, ok)
+		require.Equal(t, int64(4), index)
+		_, ok = registry.Pubkeys([]int64{1, 10})
+		require.False(t, ok)
+	})
+
+	t.Run("new deposits", func(t *testing.T) {
+		node.mu.Lock()
+		node.count = 40
+		node.finalizedEpoch = 4
+		node.mu.Unlock()
+		require.NoError(t, registry.Refresh())
+		require.Equal(t, 40, registry.Len())
+		pubkeys, ok := registry.Pubkeys([]int64{39, 0})
+		require.True(t, ok)
+		require.Equal(t, []string{testPubkey(39), testPubkey(0)}, pubkeys)
+	})
+
+	t.Run("persisted", func(t *testing.T) {
+		restored := NewRegistry(client, file)
+		require.NoError(t, restored.Load())
+		require.Equal(t, 40, restored.Len())
+		require.Equal(t, int64(4), restored.FinalizedEpoch())
+		index, ok := restored.Index(testPubkey(33))
+		require.True(t, ok)
+		require.Equal(t, int64(33), index)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(file, data[:len(data)-10], 0o600))
+		require.ErrorIs(t, NewRegistry(client, file).Load(), ErrRegistryCorrupted)
+
+		// a count far beyond the file is refused before allocating
+		data[len(constRegistryFileMagic)+8+7] = 0x7f
+		require.NoError(t, os.WriteFile(file, data, 0o600))
+		require.ErrorIs(t, NewRegistry(client, file).Load(), ErrRegistryCorrupted)
+	})
+}
@@ -24,7 +24,9 @@ type BlockResponse struct {
 				AttesterSlashings []any  `json:"attester_slashings"`
 				Attestations      []struct {
 					AggregationBits string `json:"aggregation_bits"`
-					Data            struct {
+					// CommitteeBits are set from Electra on, the aggregation bits then span the committees set
+					CommitteeBits string `json:"committee_bits"`
+					Data          struct {
 						Slot            string `json:"slot"`
 						Index           string `json:"index"`
 						BeaconBlockRoot string `json:"beacon_block_root"`
@@ -69,14 +71,88 @@ type BlockResponse struct {
 					BlobGasUsed   string `json:"blob_gas_used"`
 					ExcessBlobGas string `json:"excess_blob_gas"`
 				} `json:"execution_payload"`
-				BlsToExecutionChanges []any `json:"bls_to_execution_changes"`
-				BlobKzgCommitments    []any `json:"blob_kzg_commitments"`
+				BlsToExecutionChanges []any    `json:"bls_to_execution_changes"`
+				BlobKzgCommitments    []string `json:"blob_kzg_commitments"`
 			} `json:"body"`
 		} `json:"message"`
 		Signature string `json:"signature"`
 	} `json:"data"`
 }
 
+type BlockHeaderResponse struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+	Data                struct {
+		Root      string `json:"root"`
+		Canonical bool   `json:"canonical"`
+		Header    struct {
+			Message struct {
+				Slot          string `json:"slot"`
+				ProposerIndex string `json:"proposer_index"`
+				ParentRoot    string `json:"parent_root"`
+				StateRoot     string `json:"state_root"`
+				BodyRoot      string `json:"body_root"`
+			} `json:"message"`
+			Signature string `json:"signature"`
+		} `json:"header"`
+	} `json:"data"`
+}
+
+// BlobSidecarsResponse leaves the blobs themselves out, 128 KiB each.
+type BlobSidecarsResponse struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+	Data                []struct {
+		Index         string `json:"index"`
+		KzgCommitment string `json:"kzg_commitment"`
+		KzgProof      string `json:"kzg_proof"`
+	} `json:"data"`
+}
+
+type ProposerDutiesResponse struct {
+	DependentRoot       string `json:"dependent_root"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+	Data                []struct {
+		Pubkey         string `json:"pubkey"`
+		ValidatorIndex string `json:"validator_index"`
+		Slot           string `json:"slot"`
+	} `json:"data"`
+}
+
+type HeadEvent struct {
+	Slot                      string `json:"slot"`
+	Block                     string `json:"block"`
+	State                     string `json:"state"`
+	EpochTransition           bool   `json:"epoch_transition"`
+	PreviousDutyDependentRoot string `json:"previous_duty_dependent_root"`
+	CurrentDutyDependentRoot  string `json:"current_duty_dependent_root"`
+	ExecutionOptimistic       bool   `json:"execution_optimistic"`
+}
+
+type BlockEvent struct {
+	Slot                string `json:"slot"`
+	Block               string `json:"block"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+type FinalizedCheckpointEvent struct {
+	Block               string `json:"block"`
+	State               string `json:"state"`
+	Epoch               string `json:"epoch"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+type ChainReorgEvent struct {
+	Slot                string `json:"slot"`
+	Depth               string `json:"depth"`
+	OldHeadBlock        string `json:"old_head_block"`
+	NewHeadBlock        string `json:"new_head_block"`
+	OldHeadState        string `json:"old_head_state"`
+	NewHeadState        string `json:"new_head_state"`
+	Epoch               string `json:"epoch"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
 type SyncDutiesResponse struct {
 	ExecutionOptimistic bool `json:"execution_optimistic"`
 	Finalized           bool `json:"finalized"`
@@ -87,22 +163,55 @@ type SyncDutiesResponse struct {
 }
 
 type ValidatorResponse struct {
+	ExecutionOptimistic bool            `json:"execution_optimistic"`
+	Finalized           bool            `json:"finalized"`
+	Data                []ValidatorData `json:"data"`
+}
+
+type SingleValidatorResponse struct {
+	ExecutionOptimistic bool          `json:"execution_optimistic"`
+	Finalized           bool          `json:"finalized"`
+	Data                ValidatorData `json:"data"`
+}
+
+type ValidatorData struct {
+	Index     string `json:"index"`
+	Balance   string `json:"balance"`
+	Status    string `json:"status"`
+	Validator struct {
+		Pubkey                     string `json:"pubkey"`
+		WithdrawalCredentials      string `json:"withdrawal_credentials"`
+		EffectiveBalance           string `json:"effective_balance"`
+		Slashed                    bool   `json:"slashed"`
+		ActivationEligibilityEpoch string `json:"activation_eligibility_epoch"`
+		ActivationEpoch            string `json:"activation_epoch"`
+		ExitEpoch                  string `json:"exit_epoch"`
+		WithdrawableEpoch          string `json:"withdrawable_epoch"`
+	} `json:"validator"`
+}
+
+type Checkpoint struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+type FinalityCheckpointsResponse struct {
 	ExecutionOptimistic bool `json:"execution_optimistic"`
 	Finalized           bool `json:"finalized"`
-	Data                []struct {
-		Index     string `json:"index"`
-		Balance   string `json:"balance"`
-		Status    string `json:"status"`
-		Validator struct {
-			Pubkey                     string `json:"pubkey"`
-			WithdrawalCredentials      string `json:"withdrawal_credentials"`
-			EffectiveBalance           string `json:"effective_balance"`
-			Slashed                    bool   `json:"slashed"`
-			ActivationEligibilityEpoch string `json:"activation_eligibility_epoch"`
-			ActivationEpoch            string `json:"activation_epoch"`
-			ExitEpoch                  string `json:"exit_epoch"`
-			WithdrawableEpoch          string `json:"withdrawable_epoch"`
-		} `json:"validator"`
+	Data                struct {
+		PreviousJustified Checkpoint `json:"previous_justified"`
+		CurrentJustified  Checkpoint `json:"current_justified"`
+		Finalized         Checkpoint `json:"finalized"`
+	} `json:"data"`
+}
+
+type SyncingResponse struct {
+	Data struct {
+		HeadSlot     string `json:"head_slot"`
+		SyncDistance string `json:"sync_distance"`
+		IsSyncing    bool   `json:"is_syncing"`
+		IsOptimistic bool   `json:"is_optimistic"`
+		ELOffline    bool   `json:"el_offline"`
 	} `json:"data"`
 }
 
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"ethereum-validator-api/internal/auth"
+)
+
+var apikeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "Generate an API key",
+	Long: `Prints a new random API key along with its hash. Hand the key out and add the hash
+to auth.keys in the config or to the keys file; the key itself is never stored.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, err := auth.GenerateKey()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("key:  %s\nhash: %s\n", key, auth.HashKey(key))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(apikeyCmd)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/indexer"
+	"ethereum-validator-api/internal/rewards"
+)
+
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Compute and store rewards and sync committees for a range of finalized slots",
+	Long: `Walks the slots from --from-slot to --to-slot, computes block rewards and sync committees
+and writes them to the store, so the server answers for them right away.
+The progress is checkpointed: running the same command again resumes where it stopped
+and retries the slots that failed. The server must not be running, it holds the store open.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromSlot, _ := cmd.Flags().GetInt64("from-slot")
+		toSlot, _ := cmd.Flags().GetInt64("to-slot")
+		workers, _ := cmd.Flags().GetInt("workers")
+		mode, _ := cmd.Flags().GetString("mode")
+		if mode == "" {
+			mode = viper.GetString("server.mode")
+		}
+		mode = rewards.NormalizeMode(mode)
+		if fromSlot < 0 || toSlot < fromSlot {
+			return errors.New("invalid slot range")
+		}
+		baseURL := viper.GetString("server.ethnode")
+
+		beaconClient, err := beaconadapter.NewBeaconClient(baseURL, nil)
+		if err != nil {
+			return err
+		}
+		finalizedSlot, err := beaconClient.FinalizedSlot()
+		if err != nil {
+			return err
+		}
+		if toSlot > finalizedSlot {
+			return fmt.Errorf("only finalized slots can be backfilled, the last one is %d", finalizedSlot)
+		}
+
+		s, err := openCommandStore()
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+		ix, err := indexer.NewIndexer(baseURL, viper.GetString("server.etherscankey"), mode, s)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		logrus.Infof("Backfilling slots %d-%d in %s mode with %d workers", fromSlot, toSlot, mode, workers)
+		progress, err := ix.Backfill(ctx, fromSlot, toSlot, workers, func(p *indexer.Progress) {
+			logrus.Info(p.String())
+		})
+		if progress != nil {
+			for _, failure := range progress.Failed {
+				logrus.WithError(failure.Err).Errorf("slot %d failed", failure.Slot)
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	backfillCmd.Flags().Int64("from-slot", 0, "first slot to backfill")
+	backfillCmd.Flags().Int64("to-slot", 0, "last slot to backfill")
+	backfillCmd.Flags().Int("workers", 4, "number of slots computed at the same time")
+	backfillCmd.Flags().String("mode", "", "reward mode, light or beast (default is server.mode from the config)")
+	//nolint:errcheck // That's expected
+	backfillCmd.MarkFlagRequired("from-slot")
+	//nolint:errcheck // That's expected
+	backfillCmd.MarkFlagRequired("to-slot")
+	rootCmd.AddCommand(backfillCmd)
+}
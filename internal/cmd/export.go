@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/export"
+	"ethereum-validator-api/internal/indexer"
+	"ethereum-validator-api/internal/prices"
+	"ethereum-validator-api/internal/rewards"
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/models"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the block rewards of a range of slots as CSV, NDJSON or Parquet",
+	Long: `Writes the reward breakdowns of the blocks from --from-slot to --to-slot, in slot order, to --output.
+The finalized rewards are read from the store, the others are computed from the nodes on the fly
+and stored once finalized. Missed slots have no row. With --validators only the blocks of those
+proposers are written. With --currency the rewards are also valued at the ETH price of the day
+of their block, from the prices of the config. When the store is enabled the server must not be
+running, it holds the store open.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		formatName, _ := cmd.Flags().GetString("format")
+		fromSlot, _ := cmd.Flags().GetInt64("from-slot")
+		toSlot, _ := cmd.Flags().GetInt64("to-slot")
+		validators, _ := cmd.Flags().GetInt64Slice("validators")
+		output, _ := cmd.Flags().GetString("output")
+		workers, _ := cmd.Flags().GetInt("workers")
+		mode, _ := cmd.Flags().GetString("mode")
+		currency, _ := cmd.Flags().GetString("currency")
+		if mode == "" {
+			mode = viper.GetString("server.mode")
+		}
+		mode = rewards.NormalizeMode(mode)
+		format, err := export.ParseFormat(formatName)
+		if err != nil {
+			return err
+		}
+		if fromSlot < 0 || toSlot < fromSlot {
+			return errors.New("invalid slot range")
+		}
+		baseURL := viper.GetString("server.ethnode")
+
+		beaconClient, err := beaconadapter.NewBeaconClient(baseURL, nil)
+		if err != nil {
+			return err
+		}
+		if beaconClient.MapSlotToTimestamp(toSlot).After(time.Now()) {
+			return fmt.Errorf("slot %d is in the future", toSlot)
+		}
+		var priceSource prices.Source
+		if currency != "" {
+			if priceSource, err = newPriceSource(); err != nil {
+				return err
+			}
+			if priceSource == nil {
+				return errors.New("a currency needs prices.enabled")
+			}
+		}
+		var s *store.Store
+		if viper.GetBool("store.enabled") {
+			if s, err = openCommandStore(); err != nil {
+				return err
+			}
+			defer s.Close()
+		}
+		ix, err := indexer.NewIndexer(baseURL, viper.GetString("server.etherscankey"), mode, s)
+		if err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+		if output != "-" {
+			file, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create the output file: %w", err)
+			}
+			defer file.Close()
+			out = file
+		}
+		w, err := export.NewWriter[models.RewardBreakdown](out, format)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		logrus.Infof("Exporting slots %d-%d in %s mode as %s", fromSlot, toSlot, mode, format)
+		stats, err := ix.Export(ctx, fromSlot, toSlot, workers, validators, func(reward *models.RewardBreakdown) error {
+			if priceSource != nil {
+				// a report misses nothing silently, a reward without a price fails the export
+				fiat, err := prices.Value(ctx, priceSource, currency, beaconClient.MapSlotToTimestamp(reward.Slot), reward.Reward)
+				if err != nil {
+					return fmt.Errorf("failed to value slot %d: %w", reward.Slot, err)
+				}
+				reward.Fiat = fiat
+			}
+			return w.Write(*reward)
+		})
+		if err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return fmt.Errorf("failed to write the export: %w", err)
+		}
+		logrus.Infof("Exported %d blocks, %d slots missed, %d blocks of other proposers left out",
+			stats.Exported, stats.Missed, stats.Filtered)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("format", "csv", "output format: csv, ndjson or parquet")
+	exportCmd.Flags().Int64("from-slot", 0, "first slot to export")
+	exportCmd.Flags().Int64("to-slot", 0, "last slot to export")
+	exportCmd.Flags().Int64Slice("validators", nil, "only export the blocks of these proposer indices, comma separated")
+	exportCmd.Flags().String("output", "-", "output file, - for stdout")
+	exportCmd.Flags().Int("workers", 4, "number of slots computed at the same time")
+	exportCmd.Flags().String("mode", "", "reward mode, light or beast (default is server.mode from the config)")
+	exportCmd.Flags().String("currency", "", "also value the rewards in this fiat currency, e.g. usd, with the configured prices")
+	//nolint:errcheck // That's expected
+	exportCmd.MarkFlagRequired("from-slot")
+	//nolint:errcheck // That's expected
+	exportCmd.MarkFlagRequired("to-slot")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// newHTTPServer creates the API server from the server settings.
+func newHTTPServer(handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              viper.GetString("server.port"),
+		Handler:           handler,
+		ReadHeaderTimeout: viper.GetDuration("server.read_header_timeout"),
+		ReadTimeout:       viper.GetDuration("server.read_timeout"),
+		WriteTimeout:      viper.GetDuration("server.write_timeout"),
+		IdleTimeout:       viper.GetDuration("server.idle_timeout"),
+		MaxHeaderBytes:    viper.GetInt("server.max_header_bytes"),
+	}
+}
+
+// serve runs the server on the listener until ctx is canceled, then stops accepting connections
+// and waits up to shutdownTimeout for the requests in flight. It serves TLS when certFile and keyFile are set.
+func serve(ctx context.Context, srv *http.Server, listener net.Listener, certFile, keyFile string, shutdownTimeout time.Duration) error {
+	if (certFile == "") != (keyFile == "") {
+		listener.Close()
+		return errors.New("TLS needs both a certificate and a key file")
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		if certFile != "" {
+			serveErr <- srv.ServeTLS(listener, certFile, keyFile)
+		} else {
+			serveErr <- srv.Serve(listener)
+		}
+	}()
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server stopped: %w", err)
+	case <-ctx.Done():
+	}
+
+	logrus.Info("Shutting down, draining the requests in flight")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to drain the requests in flight: %w", err)
+	}
+	return nil
+}
+
+// runInBackground starts the tasks with ctx, they are expected to return once it's canceled.
+func runInBackground(ctx context.Context, wg *sync.WaitGroup, tasks []func(context.Context)) {
+	for _, task := range tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			task(ctx)
+		}()
+	}
+}
+
+// waitTimeout waits for the wait group and tells whether it was done in time.
+func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeDrainsRequests(t *testing.T) {
+	started := make(chan struct{})
+	srv := &http.Server{
+		ReadHeaderTimeout: time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			time.Sleep(200 * time.Millisecond)
+			w.Write([]byte("done")) //nolint:errcheck // the client checks the body
+		}),
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- serve(ctx, srv, listener, "", "", 5*time.Second)
+	}()
+
+	var body []byte
+	var requestErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			requestErr = err
+			return
+		}
+		defer resp.Body.Close()
+		body, requestErr = io.ReadAll(resp.Body)
+	}()
+	<-started
+	// the request in flight is answered, new connections are refused
+	cancel()
+	require.NoError(t, <-served)
+	wg.Wait()
+	require.NoError(t, requestErr)
+	require.Equal(t, "done", string(body))
+	_, err = http.Get("http://" + listener.Addr().String())
+	require.Error(t, err)
+}
+
+func TestServeShutdownTimeout(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	srv := &http.Server{
+		ReadHeaderTimeout: time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		}),
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- serve(ctx, srv, listener, "", "", 50*time.Millisecond)
+	}()
+	go http.Get("http://" + listener.Addr().String()) //nolint:errcheck // never answered
+	<-started
+	cancel()
+	require.ErrorContains(t, <-served, "failed to drain the requests in flight")
+}
+
+func TestServeTLSNeedsBothFiles(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	err = serve(context.Background(), &http.Server{ReadHeaderTimeout: time.Second}, listener, "cert.pem", "", time.Second)
+	require.Error(t, err)
+}
+
+func TestWaitTimeout(t *testing.T) {
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	runInBackground(ctx, &wg, []func(context.Context){
+		func(ctx context.Context) { <-ctx.Done() },
+	})
+	require.False(t, waitTimeout(&wg, 20*time.Millisecond))
+	cancel()
+	require.True(t, waitTimeout(&wg, time.Second))
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"ethereum-validator-api/internal/export"
+	"ethereum-validator-api/internal/prices"
+	"ethereum-validator-api/internal/report"
+	"ethereum-validator-api/internal/rewards"
+	"ethereum-validator-api/internal/store"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Write the daily income ledger of validators over a year, for accounting",
+	Long: `Writes the income of the validators on every UTC day of --year that is finalized to <output>.csv,
+one row per validator and day, and its totals to <output>.json. A row holds the CL income (the change
+of the balance, the withdrawals added back), the priority fees and MEV payments of the blocks the
+validator proposed, its withdrawals and its balance at the end of the day, all in gwei. With --currency
+the amounts are also valued at the ETH price of their day, from the prices of the config, a missing
+price fails the report. The balances are read from the historical states of the beacon node and the
+withdrawals from Etherscan. When the store is enabled the server must not be running, it holds the
+store open.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validators, _ := cmd.Flags().GetInt64Slice("validators")
+		year, _ := cmd.Flags().GetInt("year")
+		currency, _ := cmd.Flags().GetString("currency")
+		output, _ := cmd.Flags().GetString("output")
+		workers, _ := cmd.Flags().GetInt("workers")
+		if len(validators) == 0 {
+			return errors.New("no validators")
+		}
+		if output == "" {
+			output = fmt.Sprintf("report-%d", year)
+		}
+
+		var priceSource prices.Source
+		if currency != "" {
+			var err error
+			if priceSource, err = newPriceSource(); err != nil {
+				return err
+			}
+			if priceSource == nil {
+				return errors.New("a currency needs prices.enabled")
+			}
+		}
+		var s *store.Store
+		if viper.GetBool("store.enabled") {
+			var err error
+			if s, err = openCommandStore(); err != nil {
+				return err
+			}
+			defer s.Close()
+		}
+		// only the execution part of the stored rewards is read, the mode just picks them
+		mode := rewards.NormalizeMode(viper.GetString("server.mode"))
+		reporter, err := report.NewReporter(viper.GetString("server.ethnode"), viper.GetString("server.etherscankey"), mode, s, priceSource)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		logrus.Infof("Reporting the income of %d validators in %d", len(validators), year)
+		result, err := reporter.Year(ctx, validators, year, currency, workers)
+		if err != nil {
+			return err
+		}
+		if err := writeLedger(output+".csv", result.Entries); err != nil {
+			return err
+		}
+		if err := writeSummary(output+".json", &result.Summary); err != nil {
+			return err
+		}
+		logrus.Infof("Wrote %d entries from %s to %s to %s.csv and %s.json",
+			len(result.Entries), result.Summary.From, result.Summary.To, output, output)
+		return nil
+	},
+}
+
+func writeLedger(file string, entries []report.Entry) error {
+	out, err := os.Create(file)
+	if err != nil {
+		return fmt.Errorf("failed to create the ledger file: %w", err)
+	}
+	defer out.Close()
+	w, err := export.NewWriter[report.Entry](out, export.CSV)
+	if err != nil {
+		return err
+	}
+	if err := w.Write(entries...); err != nil {
+		return fmt.Errorf("failed to write the ledger: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to write the ledger: %w", err)
+	}
+	return out.Close()
+}
+
+func writeSummary(file string, summary *report.Summary) error {
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the summary: %w", err)
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write the summary: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	reportCmd.Flags().Int64Slice("validators", nil, "validator indices to report on, comma separated")
+	reportCmd.Flags().Int("year", time.Now().UTC().Year(), "calendar year of the report, in UTC")
+	reportCmd.Flags().String("currency", "", "also value the income in this fiat currency, e.g. eur, with the configured prices")
+	reportCmd.Flags().String("output", "", "prefix of the output files (default is report-<year>)")
+	reportCmd.Flags().Int("workers", 4, "number of days fetched at the same time")
+	//nolint:errcheck // That's expected
+	reportCmd.MarkFlagRequired("validators")
+	rootCmd.AddCommand(reportCmd)
+}
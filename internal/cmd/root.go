@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"log"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"ethereum-validator-api/internal/store"
 )
 
 // rootCmd is the base command without any subcommands
@@ -38,7 +41,53 @@ func initConfig() {
 	viper.SetEnvPrefix("mewatcher")
 	viper.AutomaticEnv()
 	viper.SetDefault("server.port", ":8000")
+	viper.SetDefault("server.read_header_timeout", "10s")
+	viper.SetDefault("server.read_timeout", "30s")
+	// computing the reward of a large block in light mode takes a while
+	viper.SetDefault("server.write_timeout", "2m")
+	viper.SetDefault("server.idle_timeout", "2m")
+	viper.SetDefault("server.max_header_bytes", 1<<20)
+	viper.SetDefault("server.shutdown_timeout", "30s")
+	viper.SetDefault("server.batch_workers", 4)
 	viper.SetDefault("logging.level", "info")
+	viper.SetDefault("registry.enabled", false)
+	viper.SetDefault("registry.file", "data/validator_registry.bin")
+	viper.SetDefault("registry.refresh_interval", "6m24s")
+	viper.SetDefault("store.enabled", false)
+	viper.SetDefault("store.file", "data/store.db")
+	viper.SetDefault("indexer.follow_head", false)
+	viper.SetDefault("watch.enabled", false)
+	viper.SetDefault("watch.balance_epochs", 3)
+	viper.SetDefault("auth.enabled", false)
+	viper.SetDefault("auth.window", "1m")
+	viper.SetDefault("auth.key_rate_limit", 0)
+	viper.SetDefault("auth.ip_rate_limit", 0)
+	viper.SetDefault("auth.quota_period", "24h")
+	viper.SetDefault("auth.quota", 10000)
+	viper.SetDefault("auth.costs", map[string]int{
+		"default":           1,
+		"blockreward_light": 5,
+		"blockreward_beast": 20,
+		// a page of up to 100 slots
+		"blockrewards_light": 100,
+		"blockrewards_beast": 400,
+		"syncduties":         1,
+		"missedslots":        5,
+		// the withdrawals of up to 320 blocks
+		"withdrawals": 20,
+		// the rewards of a whole epoch
+		"epochs": 200,
+	})
+	viper.SetDefault("health.max_head_lag", 5)
+	viper.SetDefault("prices.enabled", false)
+	viper.SetDefault("prices.source", "csv")
+	viper.SetDefault("prices.file", "data/eth_prices.csv")
+	viper.SetDefault("prices.url", "https://api.coingecko.com")
+	viper.SetDefault("prices.api_key_header", "x-cg-demo-api-key")
+	viper.SetDefault("tracing.enabled", false)
+	viper.SetDefault("tracing.endpoint", "http://localhost:4318")
+	viper.SetDefault("tracing.service_name", "mewatcher")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
 	if err := viper.ReadInConfig(); err != nil {
 		log.Fatalf("Error reading config file: %v", err)
 	}
@@ -46,3 +95,13 @@ func initConfig() {
 		fmt.Println("Using config file:", viper.ConfigFileUsed())
 	}
 }
+
+// openCommandStore opens the store for a command. Only one process can have it open, so the
+// commands can't run alongside a server using it.
+func openCommandStore() (*store.Store, error) {
+	s, err := store.Open(viper.GetString("store.file"))
+	if errors.Is(err, store.ErrLocked) {
+		return nil, fmt.Errorf("%w, stop the server using %s first", err, viper.GetString("store.file"))
+	}
+	return s, err
+}
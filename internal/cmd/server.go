@@ -1,21 +1,50 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	"ethereum-validator-api/handlers"
+	"ethereum-validator-api/internal/auth"
+	"ethereum-validator-api/internal/beaconadapter"
 	"ethereum-validator-api/internal/docs"
+	"ethereum-validator-api/internal/health"
+	"ethereum-validator-api/internal/indexer"
+	"ethereum-validator-api/internal/metrics"
+	"ethereum-validator-api/internal/prices"
+	"ethereum-validator-api/internal/rewards"
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/internal/stream"
+	"ethereum-validator-api/internal/tracing"
+	"ethereum-validator-api/internal/watch"
+	"ethereum-validator-api/models"
 )
 
 var serverCmd = &cobra.Command{
 	Use:   "server",
 	Short: "Start the Gin server",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		// from here on the errors aren't about the command line
+		cmd.SilenceUsage = true
 		port := viper.GetString("server.port")
 		logLevel := viper.GetString("logging.level")
 		level, err := logrus.ParseLevel(logLevel)
@@ -25,27 +54,245 @@ var serverCmd = &cobra.Command{
 		}
 		logrus.SetLevel(level)
 		logrus.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+		logrus.AddHook(tracing.LogHook{})
 
-		logrus.Infof("Starting server on port %s", port)
+		var tracingCfg tracing.Config
+		if err := viper.UnmarshalKey("tracing", &tracingCfg); err != nil {
+			return fmt.Errorf("failed to parse the tracing config: %w", err)
+		}
+		shutdownTracing, err := tracing.Setup(context.Background(), tracingCfg)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				logrus.WithError(err).Warn("Failed to flush the spans")
+			}
+		}()
 
-		router := gin.Default()
-		router.Use(handlers.ConfigMiddleware(&handlers.AppConfig{
+		appCfg := &handlers.AppConfig{
 			BaseURL:       viper.GetString("server.ethnode"),
 			EthScanAPIKey: viper.GetString("server.etherscankey"),
 			Mode:          viper.GetString("server.mode"),
-		}))
+			NodeName:      viper.GetString("server.node_name"),
+			BatchWorkers:  viper.GetInt("server.batch_workers"),
+		}
+		if appCfg.NodeName == "" {
+			appCfg.NodeName = nodeName(appCfg.BaseURL)
+		}
+		if appCfg.Prices, err = newPriceSource(); err != nil {
+			return err
+		}
+		beaconClient, err := beaconadapter.NewBeaconClient(appCfg.BaseURL, nil)
+		if err != nil {
+			return err
+		}
+		appCfg.Beacon = beaconClient
+		executionClient, err := ethclient.Dial(appCfg.BaseURL)
+		if err != nil {
+			return fmt.Errorf("failed to connect to the execution node: %w", err)
+		}
+		defer executionClient.Close()
+		appCfg.Health = health.NewChecker(beaconClient, executionClient, viper.GetInt64("health.max_head_lag"))
+		// the background tasks only start once everything is set up
+		var tasks []func(context.Context)
+		if viper.GetBool("registry.enabled") {
+			appCfg.Registry = beaconadapter.NewRegistry(beaconClient, viper.GetString("registry.file"))
+			if err := appCfg.Registry.Load(); err != nil {
+				logrus.WithError(err).Warn("Failed to load the validator registry, it will be rebuilt")
+			}
+			refreshInterval := viper.GetDuration("registry.refresh_interval")
+			tasks = append(tasks, func(ctx context.Context) { appCfg.Registry.Run(ctx, refreshInterval) })
+		}
+		if viper.GetBool("store.enabled") {
+			appCfg.Store, err = store.Open(viper.GetString("store.file"))
+			if err != nil {
+				return err
+			}
+			defer appCfg.Store.Close()
+		}
+		if viper.GetBool("watch.enabled") {
+			watcher, err := newWatcher(appCfg)
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, watcher.Run)
+		}
+		if appCfg.Store != nil && viper.GetBool("indexer.follow_head") {
+			mode := rewards.NormalizeMode(appCfg.Mode)
+			ix, err := indexer.NewIndexer(appCfg.BaseURL, appCfg.EthScanAPIKey, mode, appCfg.Store)
+			if err != nil {
+				return err
+			}
+			appCfg.Stream = stream.NewHub()
+			ix.OnReward = func(reward *models.RewardBreakdown) {
+				appCfg.Stream.Publish(reward)
+				if appCfg.Watchlist != nil && appCfg.Watchlist.Contains(reward.ProposerIndex) {
+					label := metrics.ValidatorLabel(reward.ProposerIndex)
+					metrics.WatchedProposalReward.WithLabelValues(label).Set(float64(reward.Reward))
+				}
+			}
+			tasks = append(tasks, ix.Follow)
+		}
+
+		var authCfg auth.Config
+		if err := viper.UnmarshalKey("auth", &authCfg); err != nil {
+			return fmt.Errorf("failed to parse the auth config: %w", err)
+		}
+		var keyring *auth.Keyring
+		if authCfg.Enabled {
+			keyring, err = auth.NewKeyring(authCfg.Keys, authCfg.KeysFile)
+			if err != nil {
+				return err
+			}
+			logrus.Infof("API keys required, %d configured", keyring.Len())
+		}
+
+		router := gin.Default()
+		// the client addresses are rate limited, only the proxies in front of the server may set them
+		if err := router.SetTrustedProxies(viper.GetStringSlice("server.trusted_proxies")); err != nil {
+			return fmt.Errorf("failed to set the trusted proxies: %w", err)
+		}
+		router.Use(otelgin.Middleware(tracingCfg.ServiceName, otelgin.WithFilter(untracedRoute)))
+		router.Use(handlers.MetricsMiddleware())
+		router.Use(handlers.ConfigMiddleware(appCfg))
 		docs.SwaggerInfo.BasePath = ""
-		router.GET("/blockreward/:slot", handlers.GetBlockReward)
-		router.GET("/syncduties/:slot", handlers.GetSyncDuties)
+		// the probes, the metrics and the docs are left out of the auth and the limits
+		api := router.Group("", handlers.AuthMiddleware(keyring, auth.NewLimiter(authCfg)))
+		registerV1Routes(api.Group("/v1"))
+		// the root routes from before the namespaces are kept for the existing clients
+		registerV1Routes(api.Group("", handlers.DeprecationMiddleware("/v1")))
+		registerV2Routes(api.Group("/v2"))
+		router.GET("/healthz", handlers.GetHealthz)
+		router.GET("/readyz", handlers.GetReadyz)
+		router.GET("/status", handlers.GetStatus)
+		router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-		if err := router.Run(port); err != nil {
-			logrus.Fatalf("Failed to start server: %v", err)
+		srv := newHTTPServer(router)
+		if appCfg.Stream != nil {
+			// the streams never end by themselves and would hold the shutdown up
+			srv.RegisterOnShutdown(appCfg.Stream.Close)
 		}
+		listener, err := net.Listen("tcp", port)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", port, err)
+		}
+		logrus.Infof("Starting server on port %s", port)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		var background sync.WaitGroup
+		runInBackground(ctx, &background, tasks)
+		shutdownTimeout := viper.GetDuration("server.shutdown_timeout")
+		err = serve(ctx, srv, listener, viper.GetString("server.tls.cert_file"), viper.GetString("server.tls.key_file"), shutdownTimeout)
+		// a second signal kills the process right away
+		stop()
+		if !waitTimeout(&background, shutdownTimeout) {
+			err = errors.Join(err, errors.New("background tasks did not stop in time"))
+		}
+		if err != nil {
+			return err
+		}
+		logrus.Info("Server stopped")
 		return nil
 	},
 }
 
+func registerV1Routes(group *gin.RouterGroup) {
+	group.GET("/blockreward/:slot", handlers.GetBlockReward)
+	group.GET("/blockrewards", handlers.GetBlockRewards)
+	group.POST("/blockrewards", handlers.PostBlockRewards)
+	group.GET("/syncduties/:slot", handlers.GetSyncDuties)
+	group.GET("/epochs/:epoch", handlers.GetEpoch)
+	group.GET("/validators/:id", handlers.GetValidator)
+	group.GET("/missedslots", handlers.GetMissedSlots)
+	group.GET("/withdrawals", handlers.GetWithdrawals)
+	group.GET("/blocks/:slot/withdrawals", handlers.GetBlockWithdrawals)
+	group.GET("/blocks/:slot/blobs", handlers.GetBlockBlobs)
+	group.GET("/stream/blockrewards", handlers.StreamBlockRewards)
+	group.GET("/stream/blockrewards/ws", handlers.StreamBlockRewardsWS)
+	group.GET("/watchlist", handlers.GetWatchlist)
+	group.POST("/watchlist", handlers.PostWatchlist)
+	group.DELETE("/watchlist/:index", handlers.DeleteWatchlist)
+}
+
+func registerV2Routes(group *gin.RouterGroup) {
+	group.GET("/blockreward/:slot", handlers.GetBlockRewardV2)
+	group.GET("/blockrewards", handlers.GetBlockRewardsV2)
+	group.POST("/blockrewards", handlers.PostBlockRewardsV2)
+	group.GET("/syncduties/:slot", handlers.GetSyncDutiesV2)
+	group.GET("/epochs/:epoch", handlers.GetEpochV2)
+	group.GET("/validators/:id", handlers.GetValidatorV2)
+	group.GET("/missedslots", handlers.GetMissedSlotsV2)
+	group.GET("/withdrawals", handlers.GetWithdrawalsV2)
+	group.GET("/blocks/:slot/withdrawals", handlers.GetBlockWithdrawalsV2)
+	group.GET("/blocks/:slot/blobs", handlers.GetBlockBlobsV2)
+	group.GET("/stream/blockrewards", handlers.StreamBlockRewardsV2)
+	group.GET("/stream/blockrewards/ws", handlers.StreamBlockRewardsWSV2)
+	group.GET("/watchlist", handlers.GetWatchlistV2)
+	group.POST("/watchlist", handlers.PostWatchlistV2)
+	group.DELETE("/watchlist/:index", handlers.DeleteWatchlistV2)
+}
+
+// untracedRoute leaves the probes, the scrapes and the docs out of the traces.
+func untracedRoute(req *http.Request) bool {
+	switch req.URL.Path {
+	case "/healthz", "/readyz", "/metrics":
+		return false
+	}
+	return !strings.HasPrefix(req.URL.Path, "/swagger/")
+}
+
+// nodeName names the node by the host of its URL, the path and the credentials may hold secrets.
+func nodeName(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// newPriceSource is the price source of the config, nil when fiat valuation is disabled.
+func newPriceSource() (prices.Source, error) {
+	var cfg prices.Config
+	if err := viper.UnmarshalKey("prices", &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse the prices config: %w", err)
+	}
+	return prices.NewSource(cfg)
+}
+
+// newWatcher seeds the watchlist from the config and sets up the checks of the alerting rules.
+func newWatcher(appCfg *handlers.AppConfig) (*watch.Watcher, error) {
+	beaconClient, err := beaconadapter.NewBeaconClient(appCfg.BaseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	appCfg.Watchlist, err = watch.NewWatchlist(appCfg.Store)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range viper.GetStringSlice("watch.validators") {
+		index, err := watch.ResolveIndex(beaconClient, appCfg.Registry, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve watched validator %s: %w", id, err)
+		}
+		if err := appCfg.Watchlist.Add(index); err != nil {
+			return nil, err
+		}
+	}
+	var webhooks []watch.Webhook
+	if err := viper.UnmarshalKey("watch.webhooks", &webhooks); err != nil {
+		return nil, fmt.Errorf("failed to parse the webhooks: %w", err)
+	}
+	notifier, err := watch.NewNotifier(webhooks, nil)
+	if err != nil {
+		return nil, err
+	}
+	return watch.NewWatcher(beaconClient, appCfg.Watchlist, notifier,
+		viper.GetStringSlice("watch.rules"), viper.GetInt("watch.balance_epochs"))
+}
+
 func init() {
 	rootCmd.AddCommand(serverCmd)
 }
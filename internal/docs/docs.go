@@ -35,6 +35,22 @@ const docTemplate = `{
                         "name": "slot",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "light",
+                            "full"
+                        ],
+                        "type": "string",
+                        "description": "Reward mode, server.mode by default",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fiat currency to value the reward in, e.g. usd, when prices are enabled",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -42,6 +58,12 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.BlockReward"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from the store of finalized slots, MISS otherwise"
+                            }
                         }
                     },
                     "400": {
@@ -50,12 +72,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Error"
                         }
                     },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "the API key may not request the mode",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
                     "404": {
                         "description": "the slot does not exist / was missed",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
                     },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
                     "500": {
                         "description": "internal server error",
                         "schema": {
@@ -65,19 +105,208 @@ const docTemplate = `{
                 }
             }
         },
-        "/syncduties/{slot}": {
+        "/blockrewards": {
             "get": {
-                "description": "Get the pubkeys of the validators in the sync committee for a specific slot",
+                "description": "Get the rewards of the slots in [from_slot, to_slot], a page of at most limit slots at a time.\nThe slots that fail, the missed ones included, carry their error instead of failing the page.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "rewards"
+                ],
+                "summary": "Get the rewards of a slot range",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "First slot of the range",
+                        "name": "from_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last slot of the range",
+                        "name": "to_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slots per page, 32 by default, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "light",
+                            "full"
+                        ],
+                        "type": "string",
+                        "description": "Reward mode, server.mode by default",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fiat currency to value the reward in, e.g. usd, when prices are enabled",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Answer format, negotiated from the Accept header by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockRewards"
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "the API key may not request the mode",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Get the rewards of up to 100 slots, in the order they are listed, without the duplicates.\nThe slots that fail, the missed and the future ones included, carry their error instead of failing the batch.",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "rewards"
+                ],
+                "summary": "Get the rewards of a list of slots",
+                "parameters": [
+                    {
+                        "description": "Slots",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockRewardsRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "light",
+                            "full"
+                        ],
+                        "type": "string",
+                        "description": "Reward mode, server.mode by default",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fiat currency to value the reward in, e.g. usd, when prices are enabled",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Answer format, negotiated from the Accept header by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockRewards"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "the API key may not request the mode",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/blocks/{slot}/blobs": {
+            "get": {
+                "description": "List the blobs of the block of a slot with their KZG commitments, versioned hashes and proofs, along with the blob gas used,\nthe blob base fee and the blob fees burnt. The proofs come from the blob sidecars and are left out once the node pruned them.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "syncduties"
+                    "blobs"
                 ],
-                "summary": "Get sync duties for given slot",
+                "summary": "Get the blobs of a block",
                 "parameters": [
                     {
                         "type": "integer",
@@ -91,7 +320,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SyncDuties"
+                            "$ref": "#/definitions/models.SlotBlobs"
                         }
                     },
                     "400": {
@@ -100,12 +329,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Error"
                         }
                     },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
                     "404": {
                         "description": "the slot does not exist / was missed",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
                     },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
                     "500": {
                         "description": "internal server error",
                         "schema": {
@@ -114,35 +355,3678 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.BlockReward": {
-            "type": "object",
-            "properties": {
-                "reward": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "boolean"
+        },
+        "/blocks/{slot}/withdrawals": {
+            "get": {
+                "description": "List the withdrawals of the block of a slot, each a partial skim of the balance above the max or the full exit of its validator",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "withdrawals"
+                ],
+                "summary": "Get the withdrawals of a block",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot Number",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SlotWithdrawals"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from the store of finalized slots, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "the slot does not exist / was missed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
                 }
             }
         },
-        "models.Error": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string"
+        "/epochs/{epoch}": {
+            "get": {
+                "description": "Sum up the epoch: proposed and missed slots, EL rewards of the proposers (fees, burns, MEV payments),\nCL rewards of the proposers, MEV-boost share, attestation participation, finality and sync committee period.\nThe participation counts the attestations included up to the end of the next epoch, it is provisional until then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "epochs"
+                ],
+                "summary": "Get epoch summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Epoch Number",
+                        "name": "epoch",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EpochSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "epoch is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
                 }
             }
         },
-        "models.SyncDuties": {
-            "type": "object",
-            "properties": {
-                "validators": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+        "/healthz": {
+            "get": {
+                "description": "Answers as long as the process is up, whatever the state of the upstreams",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/missedslots": {
+            "get": {
+                "description": "List the slots without a block in the given range, with the validator scheduled to propose each of them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slots"
+                ],
+                "summary": "Get missed slots",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "First slot of the range",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last slot of the range, at most 320 slots after from",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MissedSlots"
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "the beacon node doesn't serve the proposer duties of the range",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Ready when the beacon node is reachable and synced with a recent head, and the execution node is reachable",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Readiness"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "not ready, with the reasons",
+                        "schema": {
+                            "$ref": "#/definitions/models.Readiness"
+                        }
+                    }
+                }
+            }
+        },
+        "/status": {
+            "get": {
+                "description": "Head, sync distance, latency and last error of every upstream node, checked on request",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Upstream status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream/blockrewards": {
+            "get": {
+                "description": "Push the reward breakdown of every new head block as server-sent events, as soon as it is computed.\nThe event id is the slot; on reconnect the rewards stored after the Last-Event-ID slot are replayed first.\nA slot is sent again when a reorg changes its block.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "rewards"
+                ],
+                "summary": "Stream block rewards",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only blocks of this proposer",
+                        "name": "proposer_index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only blocks paying this fee recipient",
+                        "name": "fee_recipient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only MEV / non-MEV blocks",
+                        "name": "mev",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slot of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RewardBreakdown"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "the head follower is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream/blockrewards/ws": {
+            "get": {
+                "description": "The WebSocket variant of /stream/blockrewards: every message is a reward breakdown as JSON.\nPass the slot of the last message received as last_event_id to resume after reconnecting.",
+                "tags": [
+                    "rewards"
+                ],
+                "summary": "Stream block rewards over WebSocket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only blocks of this proposer",
+                        "name": "proposer_index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only blocks paying this fee recipient",
+                        "name": "fee_recipient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only MEV / non-MEV blocks",
+                        "name": "mev",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slot of the last message received",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/models.RewardBreakdown"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "the head follower is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/syncduties/{slot}": {
+            "get": {
+                "description": "Get the pubkeys of the validators in the sync committee for a specific slot.\nThe validators are listed once each, by index. With detail=true, full validator records (models.SyncDutiesDetail)\nare returned in committee order instead, a validator once per seat.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "syncduties"
+                ],
+                "summary": "Get sync duties for given slot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot Number",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return full validator records",
+                        "name": "detail",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SyncDuties"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from the store of finalized slots, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "the slot does not exist / was missed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/blockreward/{slot}": {
+            "get": {
+                "description": "Get the reward for a specific slot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rewards"
+                ],
+                "summary": "Get slot reward",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot Number",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "light",
+                            "full"
+                        ],
+                        "type": "string",
+                        "description": "Reward mode, server.mode by default",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fiat currency to value the reward in, e.g. usd, when prices are enabled",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockReward"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from the store of finalized slots, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "the API key may not request the mode",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "the slot does not exist / was missed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/blockrewards": {
+            "get": {
+                "description": "Get the rewards of the slots in [from_slot, to_slot], a page of at most limit slots at a time.\nThe slots that fail, the missed ones included, carry their error instead of failing the page.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "rewards"
+                ],
+                "summary": "Get the rewards of a slot range",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "First slot of the range",
+                        "name": "from_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last slot of the range",
+                        "name": "to_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slots per page, 32 by default, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "light",
+                            "full"
+                        ],
+                        "type": "string",
+                        "description": "Reward mode, server.mode by default",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fiat currency to value the reward in, e.g. usd, when prices are enabled",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Answer format, negotiated from the Accept header by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockRewards"
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "the API key may not request the mode",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Get the rewards of up to 100 slots, in the order they are listed, without the duplicates.\nThe slots that fail, the missed and the future ones included, carry their error instead of failing the batch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "rewards"
+                ],
+                "summary": "Get the rewards of a list of slots",
+                "parameters": [
+                    {
+                        "description": "Slots",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockRewardsRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "light",
+                            "full"
+                        ],
+                        "type": "string",
+                        "description": "Reward mode, server.mode by default",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fiat currency to value the reward in, e.g. usd, when prices are enabled",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Answer format, negotiated from the Accept header by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockRewards"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "the API key may not request the mode",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/blocks/{slot}/blobs": {
+            "get": {
+                "description": "List the blobs of the block of a slot with their KZG commitments, versioned hashes and proofs, along with the blob gas used,\nthe blob base fee and the blob fees burnt. The proofs come from the blob sidecars and are left out once the node pruned them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "blobs"
+                ],
+                "summary": "Get the blobs of a block",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot Number",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SlotBlobs"
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "the slot does not exist / was missed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/blocks/{slot}/withdrawals": {
+            "get": {
+                "description": "List the withdrawals of the block of a slot, each a partial skim of the balance above the max or the full exit of its validator",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "withdrawals"
+                ],
+                "summary": "Get the withdrawals of a block",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot Number",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SlotWithdrawals"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from the store of finalized slots, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "the slot does not exist / was missed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/epochs/{epoch}": {
+            "get": {
+                "description": "Sum up the epoch: proposed and missed slots, EL rewards of the proposers (fees, burns, MEV payments),\nCL rewards of the proposers, MEV-boost share, attestation participation, finality and sync committee period.\nThe participation counts the attestations included up to the end of the next epoch, it is provisional until then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "epochs"
+                ],
+                "summary": "Get epoch summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Epoch Number",
+                        "name": "epoch",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EpochSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "epoch is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/missedslots": {
+            "get": {
+                "description": "List the slots without a block in the given range, with the validator scheduled to propose each of them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slots"
+                ],
+                "summary": "Get missed slots",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "First slot of the range",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last slot of the range, at most 320 slots after from",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MissedSlots"
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "422": {
+                        "description": "the beacon node doesn't serve the proposer duties of the range",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stream/blockrewards": {
+            "get": {
+                "description": "Push the reward breakdown of every new head block as server-sent events, as soon as it is computed.\nThe event id is the slot; on reconnect the rewards stored after the Last-Event-ID slot are replayed first.\nA slot is sent again when a reorg changes its block.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "rewards"
+                ],
+                "summary": "Stream block rewards",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only blocks of this proposer",
+                        "name": "proposer_index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only blocks paying this fee recipient",
+                        "name": "fee_recipient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only MEV / non-MEV blocks",
+                        "name": "mev",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slot of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RewardBreakdown"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "the head follower is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/stream/blockrewards/ws": {
+            "get": {
+                "description": "The WebSocket variant of /stream/blockrewards: every message is a reward breakdown as JSON.\nPass the slot of the last message received as last_event_id to resume after reconnecting.",
+                "tags": [
+                    "rewards"
+                ],
+                "summary": "Stream block rewards over WebSocket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only blocks of this proposer",
+                        "name": "proposer_index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only blocks paying this fee recipient",
+                        "name": "fee_recipient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only MEV / non-MEV blocks",
+                        "name": "mev",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slot of the last message received",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/models.RewardBreakdown"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "the head follower is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/syncduties/{slot}": {
+            "get": {
+                "description": "Get the pubkeys of the validators in the sync committee for a specific slot.\nThe validators are listed once each, by index. With detail=true, full validator records (models.SyncDutiesDetail)\nare returned in committee order instead, a validator once per seat.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "syncduties"
+                ],
+                "summary": "Get sync duties for given slot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot Number",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return full validator records",
+                        "name": "detail",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SyncDuties"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from the store of finalized slots, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "the slot does not exist / was missed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/validators/{id}": {
+            "get": {
+                "description": "Get status, balances, lifecycle epochs and withdrawal credentials of a validator",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validators"
+                ],
+                "summary": "Get validator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validator index or 0x-prefixed pubkey",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "head (default), genesis, finalized, justified, slot number or 0x state root",
+                        "name": "state",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Validator"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "the validator or the state does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/watchlist": {
+            "get": {
+                "description": "List the indices of the validators the alerting rules are checked for",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Get the watchlist",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Watchlist"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "the watchlist is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Watch validators by index or pubkey. Validators already watched are left as they are.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Add validators to the watchlist",
+                "parameters": [
+                    {
+                        "description": "Validator indices or pubkeys",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid validator index or pubkey",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "unknown pubkey",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "the watchlist is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/watchlist/{index}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Remove a validator from the watchlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Validator index",
+                        "name": "index",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid validator index",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "the validator is not watched",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "the watchlist is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/withdrawals": {
+            "get": {
+                "description": "List the withdrawals of the blocks in [from_slot, to_slot], each a partial skim of the balance above the max or the full exit of its validator.\nThe withdrawals can be narrowed down to those of a validator or to an address.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "withdrawals"
+                ],
+                "summary": "Get withdrawals",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "First slot of the range",
+                        "name": "from_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last slot of the range, at most 320 slots after from_slot",
+                        "name": "to_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only the withdrawals of this validator index",
+                        "name": "validator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the withdrawals to this execution address",
+                        "name": "address",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Withdrawals"
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/blockreward/{slot}": {
+            "get": {
+                "description": "Get the reward for a specific slot, in the v2 envelope",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get slot reward",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot Number",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "light",
+                            "full"
+                        ],
+                        "type": "string",
+                        "description": "Reward mode, server.mode by default",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fiat currency to value the reward in, e.g. usd, when prices are enabled",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.BlockReward"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from the store of finalized slots, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "the API key may not request the mode",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "the slot does not exist / was missed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/blockrewards": {
+            "get": {
+                "description": "Get the rewards of the slots in [from_slot, to_slot], a page of at most limit slots at a time, in the v2 envelope.\nThe slots that fail, the missed ones included, carry their error instead of failing the page.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get the rewards of a slot range",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "First slot of the range",
+                        "name": "from_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last slot of the range",
+                        "name": "to_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slots per page, 32 by default, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "light",
+                            "full"
+                        ],
+                        "type": "string",
+                        "description": "Reward mode, server.mode by default",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fiat currency to value the reward in, e.g. usd, when prices are enabled",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Answer format, negotiated from the Accept header by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.BlockRewards"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "the API key may not request the mode",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Get the rewards of up to 100 slots, in the order they are listed, without the duplicates, in the v2 envelope.\nThe slots that fail, the missed and the future ones included, carry their error instead of failing the batch.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get the rewards of a list of slots",
+                "parameters": [
+                    {
+                        "description": "Slots",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BlockRewardsRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "light",
+                            "full"
+                        ],
+                        "type": "string",
+                        "description": "Reward mode, server.mode by default",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fiat currency to value the reward in, e.g. usd, when prices are enabled",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Answer format, negotiated from the Accept header by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.BlockRewards"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "the API key may not request the mode",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/blocks/{slot}/blobs": {
+            "get": {
+                "description": "List the blobs of the block of a slot with their KZG commitments, versioned hashes and proofs, along with the blob gas used,\nthe blob base fee and the blob fees burnt, in the v2 envelope. The proofs are left out once the node pruned the sidecars.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get the blobs of a block",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot Number",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SlotBlobs"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "the slot does not exist / was missed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/blocks/{slot}/withdrawals": {
+            "get": {
+                "description": "List the withdrawals of the block of a slot, each a partial skim of the balance above the max or the full exit of its validator, in the v2 envelope",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get the withdrawals of a block",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot Number",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SlotWithdrawals"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from the store of finalized slots, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "the slot does not exist / was missed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/epochs/{epoch}": {
+            "get": {
+                "description": "Sum up the epoch, in the v2 envelope: proposed and missed slots, EL rewards of the proposers (fees, burns, MEV payments),\nCL rewards of the proposers, MEV-boost share, attestation participation, finality and sync committee period.\nThe participation counts the attestations included up to the end of the next epoch, it is provisional until then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get epoch summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Epoch Number",
+                        "name": "epoch",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.EpochSummary"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "epoch is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/missedslots": {
+            "get": {
+                "description": "List the slots without a block in the given range, with the validator scheduled to propose each of them, in the v2 envelope",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get missed slots",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "First slot of the range",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last slot of the range, at most 320 slots after from",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MissedSlots"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/stream/blockrewards": {
+            "get": {
+                "description": "The server-sent events of /v1/stream/blockrewards, every event data is a reward breakdown in the v2 envelope",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Stream block rewards",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only blocks of this proposer",
+                        "name": "proposer_index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only blocks paying this fee recipient",
+                        "name": "fee_recipient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only MEV / non-MEV blocks",
+                        "name": "mev",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slot of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.RewardBreakdown"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "503": {
+                        "description": "the head follower is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/stream/blockrewards/ws": {
+            "get": {
+                "description": "The WebSocket variant of /v2/stream/blockrewards: every message is a reward breakdown in the v2 envelope",
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Stream block rewards over a WebSocket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only blocks of this proposer",
+                        "name": "proposer_index",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only blocks paying this fee recipient",
+                        "name": "fee_recipient",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only MEV / non-MEV blocks",
+                        "name": "mev",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slot of the last message received",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.RewardBreakdown"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "503": {
+                        "description": "the head follower is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/syncduties/{slot}": {
+            "get": {
+                "description": "Get the pubkeys of the validators in the sync committee for a specific slot, in the v2 envelope.\nWith detail=true, full validator records (models.SyncDutiesDetail) are returned in committee order instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get sync duties for given slot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot Number",
+                        "name": "slot",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return full validator records",
+                        "name": "detail",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SyncDuties"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from the store of finalized slots, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "the slot does not exist / was missed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/validators/{id}": {
+            "get": {
+                "description": "Get status, balances, lifecycle epochs and withdrawal credentials of a validator, in the v2 envelope.\nThe slot and the epoch are in the meta when the state is a slot number.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get validator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validator index or 0x-prefixed pubkey",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "head (default), genesis, finalized, justified, slot number or 0x state root",
+                        "name": "state",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Validator"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "the validator or the state does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/watchlist": {
+            "get": {
+                "description": "List the indices of the validators the alerting rules are checked for, in the v2 envelope",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get the watchlist",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Watchlist"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "503": {
+                        "description": "the watchlist is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Watch validators by index or pubkey, in the v2 envelope. Validators already watched are left as they are.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Add validators to the watchlist",
+                "parameters": [
+                    {
+                        "description": "Validator indices or pubkeys",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Watchlist"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "invalid validator index or pubkey",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "unknown pubkey",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "503": {
+                        "description": "the watchlist is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/watchlist/{index}": {
+            "delete": {
+                "description": "Stop watching a validator, in the v2 envelope",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Remove a validator from the watchlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Validator index",
+                        "name": "index",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Watchlist"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "invalid validator index",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "the validator is not watched",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "503": {
+                        "description": "the watchlist is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/withdrawals": {
+            "get": {
+                "description": "List the withdrawals of the blocks in [from_slot, to_slot], each a partial skim of the balance above the max or the full exit of its validator, in the v2 envelope.\nThe withdrawals can be narrowed down to those of a validator or to an address.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get withdrawals",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "First slot of the range",
+                        "name": "from_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last slot of the range, at most 320 slots after from_slot",
+                        "name": "to_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only the withdrawals of this validator index",
+                        "name": "validator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the withdrawals to this execution address",
+                        "name": "address",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Withdrawals"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/validators/{id}": {
+            "get": {
+                "description": "Get status, balances, lifecycle epochs and withdrawal credentials of a validator",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validators"
+                ],
+                "summary": "Get validator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validator index or 0x-prefixed pubkey",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "head (default), genesis, finalized, justified, slot number or 0x state root",
+                        "name": "state",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Validator"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "the validator or the state does not exist",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/watchlist": {
+            "get": {
+                "description": "List the indices of the validators the alerting rules are checked for",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Get the watchlist",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Watchlist"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "the watchlist is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Watch validators by index or pubkey. Validators already watched are left as they are.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Add validators to the watchlist",
+                "parameters": [
+                    {
+                        "description": "Validator indices or pubkeys",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WatchlistRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid validator index or pubkey",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "unknown pubkey",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "the watchlist is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/watchlist/{index}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Remove a validator from the watchlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Validator index",
+                        "name": "index",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid validator index",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "the validator is not watched",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "the watchlist is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/withdrawals": {
+            "get": {
+                "description": "List the withdrawals of the blocks in [from_slot, to_slot], each a partial skim of the balance above the max or the full exit of its validator.\nThe withdrawals can be narrowed down to those of a validator or to an address.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "withdrawals"
+                ],
+                "summary": "Get withdrawals",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "First slot of the range",
+                        "name": "from_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last slot of the range, at most 320 slots after from_slot",
+                        "name": "to_slot",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only the withdrawals of this validator index",
+                        "name": "validator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the withdrawals to this execution address",
+                        "name": "address",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Withdrawals"
+                        }
+                    },
+                    "400": {
+                        "description": "slot is in the future / invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "missing or invalid API key, when keys are required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "rate limit or quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Blob": {
+            "type": "object",
+            "properties": {
+                "index": {
+                    "type": "integer"
+                },
+                "kzg_commitment": {
+                    "type": "string"
+                },
+                "kzg_proof": {
+                    "description": "KzgProof is unset once the node pruned the sidecars of the block, after about 18 days",
+                    "type": "string"
+                },
+                "versioned_hash": {
+                    "description": "VersionedHash is the hash the blob transactions reference the blob by",
+                    "type": "string"
+                }
+            }
+        },
+        "models.BlockReward": {
+            "type": "object",
+            "properties": {
+                "execution_optimistic": {
+                    "type": "boolean"
+                },
+                "fiat": {
+                    "description": "Fiat is the reward in the requested currency, unset when none was requested or there is no price",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FiatValue"
+                        }
+                    ]
+                },
+                "finalized": {
+                    "type": "boolean"
+                },
+                "mode": {
+                    "description": "Mode is the mode the reward was computed in, light or full",
+                    "type": "string"
+                },
+                "reward": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.BlockRewards": {
+            "type": "object",
+            "properties": {
+                "next_from_slot": {
+                    "description": "NextFromSlot is the from_slot of the next page of a range, unset on the last one",
+                    "type": "integer"
+                },
+                "rewards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SlotBlockReward"
+                    }
+                }
+            }
+        },
+        "models.BlockRewardsRequest": {
+            "type": "object",
+            "required": [
+                "slots"
+            ],
+            "properties": {
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.Envelope": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "meta": {
+                    "$ref": "#/definitions/models.Meta"
+                }
+            }
+        },
+        "models.EpochSummary": {
+            "type": "object",
+            "properties": {
+                "blob_fees": {
+                    "type": "integer"
+                },
+                "blobs": {
+                    "description": "Blobs and BlobFees sum up the blobs of the proposed blocks, their fees are burnt",
+                    "type": "integer"
+                },
+                "burnt_fees": {
+                    "type": "integer"
+                },
+                "consensus_proposer_rewards": {
+                    "description": "ConsensusProposerRewards are the CL rewards of the proposers for their blocks",
+                    "type": "integer"
+                },
+                "epoch": {
+                    "type": "integer"
+                },
+                "execution_rewards": {
+                    "type": "integer"
+                },
+                "finalized": {
+                    "type": "boolean"
+                },
+                "from_slot": {
+                    "type": "integer"
+                },
+                "mev_blocks": {
+                    "type": "integer"
+                },
+                "mev_boost_share": {
+                    "description": "MevBoostShare is the share of the proposed blocks built through MEV-boost",
+                    "type": "number"
+                },
+                "mev_payments": {
+                    "type": "integer"
+                },
+                "missed_slots": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "participation_rate": {
+                    "description": "ParticipationRate is the share of the attestation duties fulfilled, from the attestations in the blocks",
+                    "type": "number"
+                },
+                "proposed_slots": {
+                    "type": "integer"
+                },
+                "sync_committee_period": {
+                    "type": "integer"
+                },
+                "to_slot": {
+                    "type": "integer"
+                },
+                "transaction_fees": {
+                    "description": "the EL rewards of the proposers: TransactionFees - BurntFees + MevPayments",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Error": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ErrorDetail": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ErrorEnvelope": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/models.ErrorDetail"
+                },
+                "meta": {
+                    "$ref": "#/definitions/models.Meta"
+                }
+            }
+        },
+        "models.FiatValue": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Date is the UTC day of the price, YYYY-MM-DD",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Price is the price of one ETH on Date",
+                    "type": "number"
+                },
+                "reward": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Meta": {
+            "type": "object",
+            "properties": {
+                "computed_at": {
+                    "type": "string"
+                },
+                "epoch": {
+                    "type": "integer"
+                },
+                "execution_optimistic": {
+                    "type": "boolean"
+                },
+                "finalized": {
+                    "type": "boolean"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "source_node": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MissedSlot": {
+            "type": "object",
+            "properties": {
+                "epoch": {
+                    "type": "integer"
+                },
+                "finalized": {
+                    "type": "boolean"
+                },
+                "proposer_index": {
+                    "type": "integer"
+                },
+                "slot": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MissedSlots": {
+            "type": "object",
+            "properties": {
+                "from_slot": {
+                    "type": "integer"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MissedSlot"
+                    }
+                },
+                "to_slot": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Readiness": {
+            "type": "object",
+            "properties": {
+                "ready": {
+                    "type": "boolean"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.RewardBreakdown": {
+            "type": "object",
+            "properties": {
+                "blob_base_fee": {
+                    "description": "BlobBaseFee is the price of the blob gas in wei",
+                    "type": "integer"
+                },
+                "blob_fees": {
+                    "type": "integer"
+                },
+                "blob_gas_used": {
+                    "type": "integer"
+                },
+                "blobs": {
+                    "description": "the blobs of the block, none before Deneb. Their fees are burnt and aren't part of the reward.",
+                    "type": "integer"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "block_root": {
+                    "type": "string"
+                },
+                "burnt_fees": {
+                    "type": "integer"
+                },
+                "computed_at": {
+                    "description": "ComputedAt is unknown for the rewards stored before it was recorded",
+                    "type": "string"
+                },
+                "consensus_rewards": {
+                    "type": "integer"
+                },
+                "execution_optimistic": {
+                    "type": "boolean"
+                },
+                "fee_recipient": {
+                    "type": "string"
+                },
+                "fiat": {
+                    "description": "Fiat is only set on the way out, when a currency is requested, it isn't stored",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FiatValue"
+                        }
+                    ]
+                },
+                "finalized": {
+                    "type": "boolean"
+                },
+                "mev": {
+                    "type": "boolean"
+                },
+                "mev_payment": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "parent_root": {
+                    "type": "string"
+                },
+                "proposer_index": {
+                    "type": "integer"
+                },
+                "reward": {
+                    "type": "integer"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "transaction_fees": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SlotBlobs": {
+            "type": "object",
+            "properties": {
+                "blob_base_fee": {
+                    "description": "BlobBaseFee is the price of the blob gas in wei",
+                    "type": "integer"
+                },
+                "blob_count": {
+                    "type": "integer"
+                },
+                "blob_fees": {
+                    "description": "BlobFees are in gwei",
+                    "type": "integer"
+                },
+                "blob_gas_used": {
+                    "type": "integer"
+                },
+                "blobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Blob"
+                    }
+                },
+                "execution_optimistic": {
+                    "type": "boolean"
+                },
+                "finalized": {
+                    "type": "boolean"
+                },
+                "slot": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SlotBlockReward": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "missed": {
+                    "description": "Missed is set for the slots without a block",
+                    "type": "boolean"
+                },
+                "reward": {
+                    "$ref": "#/definitions/models.BlockReward"
+                },
+                "slot": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SlotWithdrawals": {
+            "type": "object",
+            "properties": {
+                "block_number": {
+                    "type": "integer"
+                },
+                "execution_optimistic": {
+                    "type": "boolean"
+                },
+                "finalized": {
+                    "type": "boolean"
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "withdrawals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Withdrawal"
+                    }
+                }
+            }
+        },
+        "models.Status": {
+            "type": "object",
+            "properties": {
+                "current_slot": {
+                    "type": "integer"
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "reasons": {
+                    "description": "Reasons tells why the service isn't ready",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "upstreams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UpstreamStatus"
+                    }
+                }
+            }
+        },
+        "models.SyncDuties": {
+            "type": "object",
+            "properties": {
+                "execution_optimistic": {
+                    "type": "boolean"
+                },
+                "finalized": {
+                    "type": "boolean"
+                },
+                "validators": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.UpstreamStatus": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "head": {
+                    "type": "integer"
+                },
+                "head_lag": {
+                    "description": "HeadLag is how many slots the beacon head is behind the wall clock",
+                    "type": "integer"
+                },
+                "is_syncing": {
+                    "type": "boolean"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_error_time": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reachable": {
+                    "type": "boolean"
+                },
+                "sync_distance": {
+                    "description": "SyncDistance is how far the node is behind the head it knows of",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Validator": {
+            "type": "object",
+            "properties": {
+                "activation_eligibility_epoch": {
+                    "type": "integer"
+                },
+                "activation_epoch": {
+                    "type": "integer"
+                },
+                "balance": {
+                    "type": "integer"
+                },
+                "effective_balance": {
+                    "type": "integer"
+                },
+                "execution_optimistic": {
+                    "type": "boolean"
+                },
+                "exit_epoch": {
+                    "type": "integer"
+                },
+                "finalized": {
+                    "type": "boolean"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "pubkey": {
+                    "type": "string"
+                },
+                "slashed": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "withdrawable_epoch": {
+                    "type": "integer"
+                },
+                "withdrawal_address": {
+                    "type": "string"
+                },
+                "withdrawal_credentials": {
+                    "type": "string"
+                },
+                "withdrawal_credentials_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Watchlist": {
+            "type": "object",
+            "properties": {
+                "validators": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.WatchlistRequest": {
+            "type": "object",
+            "required": [
+                "validators"
+            ],
+            "properties": {
+                "validators": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.Withdrawal": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "amount": {
+                    "description": "Amount is in gwei",
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "partial",
+                        "full"
+                    ]
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "validator_index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Withdrawals": {
+            "type": "object",
+            "properties": {
+                "finalized": {
+                    "description": "Finalized is true when the blocks of all the slots are finalized",
+                    "type": "boolean"
+                },
+                "from_slot": {
+                    "type": "integer"
+                },
+                "to_slot": {
+                    "type": "integer"
+                },
+                "withdrawals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Withdrawal"
                     }
                 }
             }
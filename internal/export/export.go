@@ -0,0 +1,185 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Format is a tabular format the rows can be written in.
+type Format string
+
+const (
+	CSV     Format = "csv"
+	NDJSON  Format = "ndjson"
+	Parquet Format = "parquet"
+)
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+// ParseFormat returns the format of the name, ErrUnknownFormat for any other name.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case CSV, NDJSON, Parquet:
+		return format, nil
+	}
+	return "", fmt.Errorf("%w %q, expected csv, ndjson or parquet", ErrUnknownFormat, name)
+}
+
+// ContentType is the media type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv"
+	case NDJSON:
+		return "application/x-ndjson"
+	}
+	return "application/vnd.apache.parquet"
+}
+
+// Writer writes rows of T in a format. The columns are the fields of T, named after their json tag
+// for CSV and NDJSON and after their parquet tag for Parquet. CSV flattens the nested structs into
+// columns prefixed with the name of their field. Close must be called once all rows
+// are written, Parquet writes its footer then.
+type Writer[T any] struct {
+	csv     *csv.Writer
+	ndjson  *json.Encoder
+	parquet *parquet.GenericWriter[T]
+	header  bool
+}
+
+func NewWriter[T any](w io.Writer, format Format) (*Writer[T], error) {
+	switch format {
+	case CSV:
+		return &Writer[T]{csv: csv.NewWriter(w)}, nil
+	case NDJSON:
+		return &Writer[T]{ndjson: json.NewEncoder(w)}, nil
+	case Parquet:
+		return &Writer[T]{parquet: parquet.NewGenericWriter[T](w)}, nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownFormat, format)
+}
+
+func (w *Writer[T]) Write(rows ...T) error {
+	switch {
+	case w.csv != nil:
+		return w.writeCSV(rows)
+	case w.ndjson != nil:
+		for _, row := range rows {
+			if err := w.ndjson.Encode(row); err != nil {
+				return fmt.Errorf("failed to write the row: %w", err)
+			}
+		}
+		return nil
+	}
+	if _, err := w.parquet.Write(rows); err != nil {
+		return fmt.Errorf("failed to write the rows: %w", err)
+	}
+	return nil
+}
+
+// Close flushes the rows. It writes the CSV header when no row was written.
+func (w *Writer[T]) Close() error {
+	switch {
+	case w.csv != nil:
+		if err := w.writeCSV(nil); err != nil {
+			return err
+		}
+		w.csv.Flush()
+		return w.csv.Error()
+	case w.parquet != nil:
+		return w.parquet.Close()
+	}
+	return nil
+}
+
+func (w *Writer[T]) writeCSV(rows []T) error {
+	if !w.header {
+		w.header = true
+		if err := w.csv.Write(columns(reflect.TypeFor[T]())); err != nil {
+			return fmt.Errorf("failed to write the header: %w", err)
+		}
+	}
+	for _, row := range rows {
+		if err := w.csv.Write(cells(nil, reflect.ValueOf(row))); err != nil {
+			return fmt.Errorf("failed to write the row: %w", err)
+		}
+	}
+	return nil
+}
+
+// nested tells whether the field type is a struct flattened into its own columns.
+func nested(fieldType reflect.Type) (reflect.Type, bool) {
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType, fieldType.Kind() == reflect.Struct && fieldType != reflect.TypeFor[time.Time]()
+}
+
+// columns are the json names of the fields of the struct type. The fields of nested structs
+// are prefixed with the name of the struct field.
+func columns(rowType reflect.Type) []string {
+	var names []string
+	for i := 0; i < rowType.NumField(); i++ {
+		field := rowType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		if structType, ok := nested(field.Type); ok {
+			for _, column := range columns(structType) {
+				names = append(names, name+"_"+column)
+			}
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// cells appends the cells of the fields of the struct value to record, in the order of columns.
+func cells(record []string, value reflect.Value) []string {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		structType, ok := nested(field.Type())
+		switch {
+		case !ok:
+			record = append(record, cell(field))
+		case field.Kind() == reflect.Pointer && field.IsNil():
+			record = append(record, make([]string, len(columns(structType)))...)
+		default:
+			record = cells(record, reflect.Indirect(field))
+		}
+	}
+	return record
+}
+
+// cell formats a field for CSV, the nil pointers are empty.
+func cell(value reflect.Value) string {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if t, ok := value.Interface().(time.Time); ok {
+		return t.UTC().Format(time.RFC3339)
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	}
+	return fmt.Sprint(value.Interface())
+}
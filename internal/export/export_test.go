@@ -0,0 +1,62 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/models"
+)
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("CSV")
+	require.NoError(t, err)
+	require.Equal(t, CSV, format)
+	_, err = ParseFormat("xlsx")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestWriter(t *testing.T) {
+	computedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	rows := []models.RewardBreakdown{
+		{Slot: 1, ProposerIndex: 7, FeeRecipient: "0xabc", Mode: "light", Mev: true, Reward: 25, Finalized: true, ComputedAt: &computedAt,
+			Fiat: &models.FiatValue{Currency: "usd", Date: "2024-05-01", Price: 3000, Reward: 0.000075}},
+		{Slot: 2, ProposerIndex: 8, Mode: "light", Reward: -3},
+	}
+	write := func(format Format, rows ...models.RewardBreakdown) *bytes.Buffer {
+		var buf bytes.Buffer
+		w, err := NewWriter[models.RewardBreakdown](&buf, format)
+		require.NoError(t, err)
+		require.NoError(t, w.Write(rows...))
+		require.NoError(t, w.Close())
+		return &buf
+	}
+
+	require.Equal(t, "slot,block_root,parent_root,block_number,proposer_index,fee_recipient,mode,mev,transaction_fees,"+
+		"burnt_fees,mev_payment,blobs,blob_gas_used,blob_base_fee,blob_fees,consensus_rewards,reward,finalized,execution_optimistic,computed_at,"+
+		"fiat_currency,fiat_date,fiat_price,fiat_reward\n"+
+		"1,,,0,7,0xabc,light,true,0,0,0,0,0,0,0,0,25,true,false,2024-05-01T12:00:00Z,usd,2024-05-01,3000,0.000075\n"+
+		"2,,,0,8,,light,false,0,0,0,0,0,0,0,0,-3,false,false,,,,,\n", write(CSV, rows...).String())
+	// the header is written without rows too
+	require.Contains(t, write(CSV).String(), "slot,block_root")
+
+	require.Equal(t, `{"slot":1,"parent_root":"","block_number":0,"proposer_index":7,"fee_recipient":"0xabc","mode":"light",`+
+		`"mev":true,"transaction_fees":0,"burnt_fees":0,"mev_payment":0,"blobs":0,"blob_gas_used":0,"blob_base_fee":0,"blob_fees":0,"consensus_rewards":0,"reward":25,"finalized":true,`+
+		`"execution_optimistic":false,"computed_at":"2024-05-01T12:00:00Z",`+
+		`"fiat":{"currency":"usd","date":"2024-05-01","price":3000,"reward":0.000075}}`+"\n", write(NDJSON, rows[0]).String())
+
+	buf := write(Parquet, rows...)
+	read, err := parquet.Read[models.RewardBreakdown](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, read, 2)
+	require.Equal(t, rows[0].Slot, read[0].Slot)
+	require.Equal(t, rows[0].FeeRecipient, read[0].FeeRecipient)
+	require.True(t, computedAt.Equal(*read[0].ComputedAt))
+	require.Equal(t, rows[0].Fiat, read[0].Fiat)
+	require.Nil(t, read[1].ComputedAt)
+	require.Nil(t, read[1].Fiat)
+	require.EqualValues(t, -3, read[1].Reward)
+}
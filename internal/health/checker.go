@@ -0,0 +1,160 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/metrics"
+	"ethereum-validator-api/internal/tracing"
+	"ethereum-validator-api/models"
+)
+
+const constCheckTimeout = 5 * time.Second
+
+// Checker probes the upstream nodes for the readiness probe and the status endpoint.
+type Checker struct {
+	beacon    *beaconadapter.BeaconClient
+	execution *ethclient.Client
+	// maxHeadLag is the number of slots the beacon head may be behind the wall clock
+	maxHeadLag int64
+	timeout    time.Duration
+
+	mu sync.Mutex
+	// lastErrors keeps the last failure of every upstream, also once it recovered
+	lastErrors map[string]lastError
+}
+
+type lastError struct {
+	message string
+	time    time.Time
+}
+
+func NewChecker(beacon *beaconadapter.BeaconClient, execution *ethclient.Client, maxHeadLag int64) *Checker {
+	return &Checker{
+		beacon:     beacon,
+		execution:  execution,
+		maxHeadLag: maxHeadLag,
+		timeout:    constCheckTimeout,
+		lastErrors: make(map[string]lastError),
+	}
+}
+
+// Check probes the upstreams concurrently and tells whether the service is ready:
+// the beacon node is reachable, synced and its head recent enough, the execution node is reachable.
+func (h *Checker) Check(ctx context.Context) *models.Status {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+	var beaconStatus, executionStatus models.UpstreamStatus
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		beaconStatus = h.checkBeacon(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		executionStatus = h.checkExecution(ctx)
+	}()
+	wg.Wait()
+
+	status := &models.Status{
+		CurrentSlot: h.beacon.MapTimestampToSlot(time.Now()),
+		Upstreams:   []models.UpstreamStatus{beaconStatus, executionStatus},
+	}
+	switch {
+	case !beaconStatus.Reachable:
+		status.Reasons = append(status.Reasons, "beacon node is unreachable")
+	case beaconStatus.IsSyncing:
+		status.Reasons = append(status.Reasons, fmt.Sprintf("beacon node is syncing, %d slots behind", beaconStatus.SyncDistance))
+	case beaconStatus.HeadLag > h.maxHeadLag:
+		status.Reasons = append(status.Reasons, fmt.Sprintf("beacon head is %d slots behind, more than %d", beaconStatus.HeadLag, h.maxHeadLag))
+	}
+	if !executionStatus.Reachable {
+		status.Reasons = append(status.Reasons, "execution node is unreachable")
+	}
+	status.Ready = len(status.Reasons) == 0
+	return status
+}
+
+func (h *Checker) checkBeacon(ctx context.Context) models.UpstreamStatus {
+	start := time.Now()
+	syncingResp, err := h.beacon.WithContext(ctx).FetchSyncing()
+	status := models.UpstreamStatus{Name: metrics.UpstreamBeacon, LatencyMs: time.Since(start).Milliseconds(), CheckedAt: start.UTC()}
+	if err == nil {
+		status.Head, err = strconv.ParseInt(syncingResp.Data.HeadSlot, 10, 64)
+	}
+	if err == nil {
+		status.SyncDistance, err = strconv.ParseInt(syncingResp.Data.SyncDistance, 10, 64)
+	}
+	if err != nil {
+		return h.failed(status, err)
+	}
+	status.Reachable = true
+	status.IsSyncing = syncingResp.Data.IsSyncing
+	status.HeadLag = max(h.beacon.MapTimestampToSlot(time.Now())-status.Head, 0)
+	return h.withLastError(status)
+}
+
+func (h *Checker) checkExecution(ctx context.Context) models.UpstreamStatus {
+	start := time.Now()
+	head, err := h.blockNumber(ctx)
+	status := models.UpstreamStatus{Name: metrics.UpstreamExecution, LatencyMs: time.Since(start).Milliseconds(), CheckedAt: start.UTC()}
+	if err != nil {
+		return h.failed(status, err)
+	}
+	status.Head = int64(head)
+	progress, err := h.syncProgress(ctx)
+	if err != nil {
+		return h.failed(status, err)
+	}
+	status.Reachable = true
+	// the node answers nil once it's synced
+	if progress != nil {
+		status.IsSyncing = true
+		status.SyncDistance = int64(progress.HighestBlock) - int64(progress.CurrentBlock)
+	}
+	return h.withLastError(status)
+}
+
+// blockNumber and syncProgress call the execution RPC and record the calls.
+func (h *Checker) blockNumber(ctx context.Context) (uint64, error) {
+	ctx, span := tracing.StartUpstream(ctx, metrics.UpstreamExecution, "eth_blockNumber", nil)
+	start := time.Now()
+	head, err := h.execution.BlockNumber(ctx)
+	metrics.ObserveUpstream(metrics.UpstreamExecution, "eth_blockNumber", start, 0, err)
+	tracing.End(span, 0, err)
+	return head, err
+}
+
+func (h *Checker) syncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	ctx, span := tracing.StartUpstream(ctx, metrics.UpstreamExecution, "eth_syncing", nil)
+	start := time.Now()
+	progress, err := h.execution.SyncProgress(ctx)
+	metrics.ObserveUpstream(metrics.UpstreamExecution, "eth_syncing", start, 0, err)
+	tracing.End(span, 0, err)
+	return progress, err
+}
+
+func (h *Checker) failed(status models.UpstreamStatus, err error) models.UpstreamStatus {
+	h.mu.Lock()
+	h.lastErrors[status.Name] = lastError{message: err.Error(), time: status.CheckedAt}
+	h.mu.Unlock()
+	return h.withLastError(status)
+}
+
+func (h *Checker) withLastError(status models.UpstreamStatus) models.UpstreamStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if last, ok := h.lastErrors[status.Name]; ok {
+		status.LastError = last.message
+		status.LastErrorTime = &last.time
+	}
+	return status
+}
@@ -0,0 +1,103 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/beaconadapter"
+)
+
+// nodeStandIn serves the beacon sync status and the execution JSON-RPC methods the checker calls.
+type nodeStandIn struct {
+	headLag     atomic.Int64
+	beaconDown  atomic.Bool
+	execSyncing atomic.Bool
+	currentSlot func() int64
+}
+
+func (n *nodeStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == "/eth/v1/node/syncing" {
+		if n.beaconDown.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, `{"data":{"head_slot":"%d","sync_distance":"0","is_syncing":false,"is_optimistic":false,"el_offline":false}}`,
+			n.currentSlot()-n.headLag.Load())
+		return
+	}
+	var request struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var result any
+	switch request.Method {
+	case "eth_blockNumber":
+		result = "0x1458f2a"
+	case "eth_syncing":
+		result = false
+		if n.execSyncing.Load() {
+			result = map[string]string{"startingBlock": "0x0", "currentBlock": "0x1458f2a", "highestBlock": "0x1458f3e"}
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	//nolint:errcheck // the test fails on a broken answer anyway
+	json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": result})
+}
+
+func TestCheck(t *testing.T) {
+	node := &nodeStandIn{}
+	server := httptest.NewServer(node)
+	defer server.Close()
+	beacon, err := beaconadapter.NewBeaconClient(server.URL, nil)
+	require.NoError(t, err)
+	node.currentSlot = func() int64 { return beacon.MapTimestampToSlot(time.Now()) }
+	execution, err := ethclient.Dial(server.URL)
+	require.NoError(t, err)
+	defer execution.Close()
+	checker := NewChecker(beacon, execution, 5)
+
+	status := checker.Check(context.Background())
+	require.True(t, status.Ready, status.Reasons)
+	require.Len(t, status.Upstreams, 2)
+	require.True(t, status.Upstreams[0].Reachable)
+	require.Equal(t, status.CurrentSlot, status.Upstreams[0].Head)
+	require.Equal(t, int64(21335850), status.Upstreams[1].Head)
+	require.False(t, status.Upstreams[1].IsSyncing)
+	require.Empty(t, status.Upstreams[0].LastError)
+
+	node.headLag.Store(10)
+	node.execSyncing.Store(true)
+	status = checker.Check(context.Background())
+	require.False(t, status.Ready)
+	require.Equal(t, []string{"beacon head is 10 slots behind, more than 5"}, status.Reasons)
+	// a syncing execution node is still ready, its distance is reported
+	require.True(t, status.Upstreams[1].IsSyncing)
+	require.Equal(t, int64(20), status.Upstreams[1].SyncDistance)
+
+	node.beaconDown.Store(true)
+	status = checker.Check(context.Background())
+	require.False(t, status.Ready)
+	require.Equal(t, []string{"beacon node is unreachable"}, status.Reasons)
+	require.Contains(t, status.Upstreams[0].LastError, "503")
+
+	// the last error stays once the node is back
+	node.beaconDown.Store(false)
+	node.headLag.Store(0)
+	status = checker.Check(context.Background())
+	require.True(t, status.Ready, status.Reasons)
+	require.Contains(t, status.Upstreams[0].LastError, "503")
+	require.NotNil(t, status.Upstreams[0].LastErrorTime)
+}
@@ -0,0 +1,141 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const constProgressInterval = 10 * time.Second
+
+// SlotFailure is a slot the backfill could not index.
+type SlotFailure struct {
+	Slot int64
+	Err  error
+}
+
+// Progress is a snapshot of a running backfill.
+type Progress struct {
+	From, To   int64
+	Checkpoint int64
+	Indexed    int64
+	Missed     int64
+	Skipped    int64
+	Failed     []SlotFailure
+	Started    time.Time
+}
+
+// Done returns the number of slots processed, failed ones included.
+func (p *Progress) Done() int64 {
+	return p.Indexed + p.Missed + p.Skipped + int64(len(p.Failed))
+}
+
+func (p *Progress) String() string {
+	total := p.To - p.From + 1
+	rate := float64(p.Indexed+p.Missed) / time.Since(p.Started).Seconds()
+	return fmt.Sprintf("%d/%d slots: %d indexed, %d missed, %d skipped, %d failed (%.2f slots/s), checkpoint %d",
+		p.Done(), total, p.Indexed, p.Missed, p.Skipped, len(p.Failed), rate, p.Checkpoint)
+}
+
+type slotResult struct {
+	slot   int64
+	status SlotStatus
+	err    error
+}
+
+// CheckpointName is the store key of the backfill checkpoint for the mode and first slot.
+// Rerunning the backfill with the same mode and first slot resumes from the checkpoint.
+func CheckpointName(mode string, from int64) string {
+	return fmt.Sprintf("backfill/%s/%d", mode, from)
+}
+
+// Backfill indexes the slots in [from, to] with the given number of workers.
+// The checkpoint is the last slot up to which every slot was processed, so a failed
+// slot holds it back and gets retried on the next run. report is called every
+// few seconds and once at the end.
+func (ix *Indexer) Backfill(ctx context.Context, from, to int64, workers int, report func(*Progress)) (*Progress, error) {
+	checkpointName := CheckpointName(ix.mode, from)
+	progress := &Progress{From: from, To: to, Checkpoint: from - 1, Started: time.Now()}
+	checkpoint, ok, err := ix.store.GetCheckpoint(checkpointName)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		progress.Checkpoint = checkpoint
+		progress.Skipped = min(checkpoint, to) - from + 1
+	}
+
+	slots := make(chan int64)
+	results := make(chan slotResult)
+	go func() {
+		defer close(slots)
+		for slot := progress.Checkpoint + 1; slot <= to; slot++ {
+			select {
+			case slots <- slot:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	for i := 0; i < max(workers, 1); i++ {
+		go func() {
+			for slot := range slots {
+				status, err := ix.indexSlot(ctx, slot)
+				select {
+				case results <- slotResult{slot: slot, status: status, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(constProgressInterval)
+	defer ticker.Stop()
+	processed := make(map[int64]bool)
+	pending := to - progress.Checkpoint
+	for pending > 0 {
+		select {
+		case result := <-results:
+			pending--
+			switch {
+			case result.err != nil:
+				progress.Failed = append(progress.Failed, SlotFailure{Slot: result.slot, Err: result.err})
+			case result.status == SlotMissed:
+				progress.Missed++
+			case result.status == SlotSkipped:
+				progress.Skipped++
+			default:
+				progress.Indexed++
+			}
+			if result.err == nil {
+				processed[result.slot] = true
+			}
+			for processed[progress.Checkpoint+1] {
+				delete(processed, progress.Checkpoint+1)
+				progress.Checkpoint++
+			}
+		case <-ticker.C:
+			if err := ix.store.PutCheckpoint(checkpointName, progress.Checkpoint); err != nil {
+				return progress, err
+			}
+			report(progress)
+		case <-ctx.Done():
+			// the slots in flight are not counted, they are retried on the next run
+			pending = 0
+		}
+	}
+	if err := ix.store.PutCheckpoint(checkpointName, progress.Checkpoint); err != nil {
+		return progress, err
+	}
+	sort.Slice(progress.Failed, func(i, j int) bool { return progress.Failed[i].Slot < progress.Failed[j].Slot })
+	report(progress)
+	if err := ctx.Err(); err != nil {
+		return progress, err
+	}
+	if len(progress.Failed) > 0 {
+		return progress, fmt.Errorf("%d slots failed", len(progress.Failed))
+	}
+	return progress, nil
+}
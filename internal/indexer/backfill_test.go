@@ -0,0 +1,61 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/store"
+)
+
+func TestBackfillResumes(t *testing.T) {
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+
+	var mu sync.Mutex
+	visited := make(map[int64]int)
+	broken := true
+	ix := &Indexer{store: s, mode: "light"}
+	ix.indexSlot = func(_ context.Context, slot int64) (SlotStatus, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		visited[slot]++
+		switch {
+		case slot == 15 && broken:
+			return 0, errors.New("node is down")
+		case slot%10 == 7:
+			return SlotMissed, nil
+		}
+		return SlotIndexed, nil
+	}
+	noReport := func(*Progress) {}
+
+	progress, err := ix.Backfill(context.Background(), 10, 29, 3, noReport)
+	require.Error(t, err)
+	require.Len(t, progress.Failed, 1)
+	require.Equal(t, int64(15), progress.Failed[0].Slot)
+	require.Equal(t, int64(14), progress.Checkpoint)
+	require.Equal(t, int64(2), progress.Missed)
+	require.Equal(t, int64(17), progress.Indexed)
+	checkpoint, ok, err := s.GetCheckpoint(CheckpointName("light", 10))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(14), checkpoint)
+
+	// the rerun starts right after the checkpoint, at the failed slot
+	broken = false
+	progress, err = ix.Backfill(context.Background(), 10, 29, 3, noReport)
+	require.NoError(t, err)
+	require.Empty(t, progress.Failed)
+	require.Equal(t, int64(29), progress.Checkpoint)
+	require.Equal(t, int64(5), progress.Skipped)
+	require.Equal(t, int64(20), progress.Done())
+	require.Equal(t, 1, visited[10])
+	require.Equal(t, 2, visited[15])
+	require.Equal(t, 2, visited[29])
+}
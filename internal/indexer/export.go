@@ -0,0 +1,109 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/models"
+)
+
+// constExportChunk is the number of slots an export computes before writing them, per worker
+const constExportChunk = 8
+
+// ExportStats counts the slots of an export.
+type ExportStats struct {
+	Exported int64
+	Missed   int64
+	// Filtered are the slots of other proposers
+	Filtered int64
+}
+
+// Reward returns the reward of the slot, the stored one once finalized, else computed from the nodes.
+// The finalized rewards it computes are stored. Missed slots return beaconadapter.ErrNotFound.
+func (ix *Indexer) Reward(ctx context.Context, slot int64) (*models.RewardBreakdown, error) {
+	if ix.store != nil {
+		reward, err := ix.store.GetBlockReward(slot, ix.mode)
+		if err != nil {
+			return nil, err
+		}
+		if reward != nil && reward.Finalized {
+			return reward, nil
+		}
+	}
+	blockResp, err := ix.beacon.FetchBlockResponse(slot)
+	if err != nil {
+		return nil, err
+	}
+	reward, err := ix.rewards.GetBlockRewardBreakdown(ctx, blockResp, ix.mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute the reward: %w", err)
+	}
+	if ix.store != nil && reward.Finalized {
+		if err := ix.store.PutBlockReward(reward); err != nil {
+			return nil, err
+		}
+	}
+	return reward, nil
+}
+
+// Export passes the rewards of the slots in [from, to] to write, in slot order, leaving out the missed
+// slots and, unless proposers is empty, the blocks of other proposers. The slots are computed by
+// workers a chunk at a time, the first slot that fails stops the export.
+func (ix *Indexer) Export(ctx context.Context, from, to int64, workers int, proposers []int64,
+	write func(*models.RewardBreakdown) error) (*ExportStats, error) {
+	workers = max(workers, 1)
+	wanted := make(map[int64]bool, len(proposers))
+	for _, index := range proposers {
+		wanted[index] = true
+	}
+	stats := &ExportStats{}
+	for first := from; first <= to; first += int64(workers * constExportChunk) {
+		last := min(to, first+int64(workers*constExportChunk)-1)
+		// the blocks of a chunk share their lookups
+		chunk := *ix
+		chunk.rewards = ix.rewards.ForBatch()
+		rewards := make([]*models.RewardBreakdown, last-first+1)
+		errs := make([]error, len(rewards))
+		slots := make(chan int64)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for slot := range slots {
+					rewards[slot-first], errs[slot-first] = chunk.Reward(ctx, slot)
+				}
+			}()
+		}
+		for slot := first; slot <= last; slot++ {
+			slots <- slot
+		}
+		close(slots)
+		wg.Wait()
+
+		for i, reward := range rewards {
+			switch err := errs[i]; {
+			case errors.Is(err, beaconadapter.ErrNotFound):
+				stats.Missed++
+				continue
+			case err != nil:
+				return stats, fmt.Errorf("failed to export slot %d: %w", first+int64(i), err)
+			}
+			if len(wanted) > 0 && !wanted[reward.ProposerIndex] {
+				stats.Filtered++
+				continue
+			}
+			if err := write(reward); err != nil {
+				return stats, err
+			}
+			stats.Exported++
+		}
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
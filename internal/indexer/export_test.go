@@ -0,0 +1,50 @@
+package indexer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/models"
+)
+
+func TestExport(t *testing.T) {
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+	// the even slots are stored, the odd ones were missed but for slot 25 the node fails on
+	for slot := int64(10); slot < 30; slot += 2 {
+		require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: slot, Mode: "light", ProposerIndex: slot % 3, Finalized: true}))
+	}
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/25") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer node.Close()
+	ix, err := NewIndexer(node.URL, "", "light", s)
+	require.NoError(t, err)
+
+	var slots []int64
+	write := func(reward *models.RewardBreakdown) error {
+		slots = append(slots, reward.Slot)
+		return nil
+	}
+	stats, err := ix.Export(context.Background(), 10, 24, 3, []int64{1, 2}, write)
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 14, 16, 20, 22}, slots)
+	require.Equal(t, &ExportStats{Exported: 5, Missed: 7, Filtered: 3}, stats)
+
+	slots = nil
+	_, err = ix.Export(context.Background(), 20, 29, 2, nil, write)
+	require.ErrorContains(t, err, "slot 25")
+	require.Equal(t, []int64{20, 22, 24}, slots)
+}
@@ -0,0 +1,275 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"ethereum-validator-api/internal/beaconadapter"
+)
+
+const (
+	// constMaxHeadGap is how far back the follower catches up after missing heads,
+	// older slots are left to the backfill
+	constMaxHeadGap = beaconadapter.EthereumSlotsPerEpoch
+	// constHeadQueueSize gives the slow reward computation some slack behind the stream
+	constHeadQueueSize  = 64
+	constReorgQueueSize = 8
+	constReconnectDelay = 5 * time.Second
+)
+
+// ReorgError means the parent of a head block is not the block stored before it,
+// so the stored blocks from Slot on were reorged out.
+type ReorgError struct {
+	Slot int64
+}
+
+func (e *ReorgError) Error() string {
+	return fmt.Sprintf("stored blocks from slot %d were reorged out", e.Slot)
+}
+
+var followedTopics = []string{
+	beaconadapter.EventHead,
+	beaconadapter.EventBlock,
+	beaconadapter.EventFinalizedCheckpoint,
+	beaconadapter.EventChainReorg,
+}
+
+// Follow indexes new head blocks as the beacon node announces them and marks the stored
+// rewards final once a finalized checkpoint covers them. Reorged slots are dropped from
+// the store and recomputed. It reconnects to the event stream whenever it breaks and
+// only returns when the context is canceled.
+func (ix *Indexer) Follow(ctx context.Context) {
+	heads := make(chan int64, constHeadQueueSize)
+	reorgs := make(chan int64, constReorgQueueSize)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ix.indexHeads(ctx, heads, reorgs)
+	}()
+
+	for ctx.Err() == nil {
+		err := ix.beacon.SubscribeEvents(ctx, followedTopics, func(event beaconadapter.Event) error {
+			if err := ix.handleEvent(event, heads, reorgs); err != nil {
+				// a bad event is not worth a reconnect, the stream goes on with the next one
+				logrus.WithError(err).Warnf("skipped %v event", event.Topic)
+			}
+			return nil
+		})
+		if ctx.Err() != nil {
+			break
+		}
+		logrus.WithError(err).Warn("beacon event stream broke, reconnecting")
+		select {
+		case <-ctx.Done():
+		case <-time.After(constReconnectDelay):
+		}
+	}
+	<-done
+}
+
+func (ix *Indexer) handleEvent(event beaconadapter.Event, heads, reorgs chan<- int64) error {
+	switch event.Topic {
+	case beaconadapter.EventHead:
+		var head beaconadapter.HeadEvent
+		if err := json.Unmarshal(event.Data, &head); err != nil {
+			return fmt.Errorf("failed to decode head event: %w", err)
+		}
+		slot, err := strconv.ParseInt(head.Slot, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse head slot: %w", err)
+		}
+		select {
+		case heads <- slot:
+		default:
+			// the indexer catches up on the skipped slots with the next head
+			logrus.Warnf("head queue is full, slot %v is postponed", slot)
+		}
+	case beaconadapter.EventFinalizedCheckpoint:
+		var checkpoint beaconadapter.FinalizedCheckpointEvent
+		if err := json.Unmarshal(event.Data, &checkpoint); err != nil {
+			return fmt.Errorf("failed to decode finalized checkpoint event: %w", err)
+		}
+		epoch, err := strconv.ParseInt(checkpoint.Epoch, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse finalized epoch: %w", err)
+		}
+		return ix.finalize(epoch, checkpoint.Block, reorgs)
+	case beaconadapter.EventChainReorg:
+		var reorg beaconadapter.ChainReorgEvent
+		if err := json.Unmarshal(event.Data, &reorg); err != nil {
+			return fmt.Errorf("failed to decode chain reorg event: %w", err)
+		}
+		slot, err := strconv.ParseInt(reorg.Slot, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse reorg slot: %w", err)
+		}
+		depth, err := strconv.ParseInt(reorg.Depth, 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse reorg depth: %w", err)
+		}
+		logrus.Warnf("chain reorg of depth %v at slot %v", depth, slot)
+		select {
+		// clients don't agree on where the depth is counted from, so the common ancestor is recomputed too
+		case reorgs <- slot - depth:
+		default:
+			// the parent root check of the next heads still catches it
+			logrus.Warnf("reorg queue is full, reorg at slot %v is left to the parent root check", slot)
+		}
+	}
+	return nil
+}
+
+// finalize marks the stored rewards up to the finalized epoch final. When the stored block of
+// the checkpoint is not the finalized one, the stored slots are on a fork that lost: they are
+// dropped and computed again instead, and the next checkpoint marks them.
+func (ix *Indexer) finalize(epoch int64, root string, reorgs chan<- int64) error {
+	slot := epoch * beaconadapter.EthereumSlotsPerEpoch
+	// the checkpoint block is the last one up to the first slot of the epoch
+	stored, err := ix.store.LastBlockReward(ix.mode, slot-constMaxHeadGap, slot)
+	if err != nil {
+		return err
+	}
+	if stored != nil && !stored.Finalized && stored.BlockRoot != "" && root != "" && stored.BlockRoot != root {
+		from := slot - constMaxHeadGap
+		logrus.Warnf("stored block %v of slot %v is not the finalized checkpoint %v, reindexing from slot %v",
+			stored.BlockRoot, stored.Slot, root, from)
+		if err := ix.invalidate(from); err != nil {
+			return err
+		}
+		select {
+		case reorgs <- from:
+		default:
+			// the dropped slots are computed again with the next heads that reach back to them
+			logrus.Warnf("reorg queue is full, slots from %v are left to the next heads", from)
+		}
+		return nil
+	}
+	marked, err := ix.store.MarkFinalized(slot)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("epoch %v finalized, %v stored slots marked final", epoch, marked)
+	return nil
+}
+
+// indexHeads computes the rewards for the head slots in order, including the slots in
+// between two heads the stream did not announce. On a reorg the stored slots from the
+// reorged one on are dropped and computed again.
+func (ix *Indexer) indexHeads(ctx context.Context, heads, reorgs <-chan int64) {
+	lastSlot := int64(-1)
+	for {
+		var from, to int64
+		select {
+		case <-ctx.Done():
+			return
+		case reorgSlot := <-reorgs:
+			if err := ix.invalidate(reorgSlot); err != nil {
+				logrus.WithError(err).Errorf("failed to drop the reorged slots from %v", reorgSlot)
+			}
+			if lastSlot < 0 {
+				continue
+			}
+			from, to = max(reorgSlot, lastSlot-constMaxHeadGap), lastSlot
+		case head := <-heads:
+			if head <= lastSlot {
+				continue
+			}
+			from, to = max(lastSlot+1, head-constMaxHeadGap), head
+			if lastSlot < 0 {
+				from = head
+			}
+		}
+		for slot := from; slot <= to; slot++ {
+			err := ix.indexHead(ctx, slot)
+			var reorgErr *ReorgError
+			if errors.As(err, &reorgErr) {
+				logrus.Warnf("parent of slot %v is not the stored block, reindexing from slot %v", slot, reorgErr.Slot)
+				if err := ix.invalidate(reorgErr.Slot); err != nil {
+					logrus.WithError(err).Errorf("failed to drop the reorged slots from %v", reorgErr.Slot)
+					continue
+				}
+				slot = reorgErr.Slot - 1
+				continue
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				logrus.WithError(err).Errorf("failed to index head slot %v", slot)
+			}
+		}
+		lastSlot = to
+	}
+}
+
+func (ix *Indexer) invalidate(from int64) error {
+	dropped, err := ix.store.InvalidateBlockRewards(from, math.MaxInt64)
+	if err != nil {
+		return err
+	}
+	if len(dropped) > 0 {
+		logrus.Infof("dropped the stored rewards of %v reorged slots from slot %v", len(dropped), from)
+	}
+	return nil
+}
+
+// IndexHead computes and stores the reward of a recent, not yet finalized slot along with
+// its block root. It returns a ReorgError when the parent of the block is not the block
+// stored before it.
+func (ix *Indexer) IndexHead(ctx context.Context, slot int64) error {
+	stored, err := ix.store.GetBlockReward(slot, ix.mode)
+	if err != nil {
+		return err
+	}
+	if stored != nil && stored.Finalized {
+		return nil
+	}
+	header, err := ix.beacon.FetchBlockHeader(slot)
+	if errors.Is(err, beaconadapter.ErrNotFound) {
+		if stored != nil {
+			// the block was reorged out and the slot is missed now
+			_, err = ix.store.InvalidateBlockRewards(slot, slot)
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	parentRoot := header.Data.Header.Message.ParentRoot
+	previous, err := ix.store.LastBlockReward(ix.mode, slot-constMaxHeadGap, slot-1)
+	if err != nil {
+		return err
+	}
+	if previous != nil && !previous.Finalized && previous.BlockRoot != "" && previous.BlockRoot != parentRoot {
+		return &ReorgError{Slot: previous.Slot}
+	}
+	if stored != nil && stored.BlockRoot == header.Data.Root && stored.ExecutionOptimistic == header.ExecutionOptimistic {
+		return nil
+	}
+	blockResp, err := ix.beacon.FetchBlockResponse(slot)
+	if err != nil {
+		return err
+	}
+	if blockResp.Data.Message.ParentRoot != parentRoot {
+		return fmt.Errorf("block of slot %d changed while indexing", slot)
+	}
+	reward, err := ix.rewards.GetBlockRewardBreakdown(ctx, blockResp, ix.mode)
+	if err != nil {
+		return fmt.Errorf("failed to compute the reward: %w", err)
+	}
+	reward.BlockRoot = header.Data.Root
+	if err := ix.store.PutBlockReward(reward); err != nil {
+		return err
+	}
+	if ix.OnReward != nil {
+		ix.OnReward(reward)
+	}
+	return nil
+}
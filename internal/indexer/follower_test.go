@@ -0,0 +1,260 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/models"
+)
+
+func TestFollow(t *testing.T) {
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+
+	indexed := make(chan int64, 10)
+	// a local stand-in for the beacon node event stream
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: head\ndata: {\"slot\":\"100\"}\n\n")
+		fmt.Fprint(w, "event: block\ndata: {\"slot\":\"103\"}\n\n")
+		fmt.Fprint(w, "event: head\ndata: {\"slot\":\"103\"}\n\n")
+		w.(http.Flusher).Flush()
+		// finalize only once the heads are in the store
+		for slot := range indexed {
+			if slot == 103 {
+				break
+			}
+		}
+		fmt.Fprint(w, "event: finalized_checkpoint\ndata: {\"epoch\":\"3\"}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer node.Close()
+
+	beaconClient, err := beaconadapter.NewBeaconClient(node.URL, nil)
+	require.NoError(t, err)
+	var mu sync.Mutex
+	var headSlots []int64
+	ix := &Indexer{store: s, mode: "light", beacon: beaconClient}
+	ix.indexHead = func(_ context.Context, slot int64) error {
+		mu.Lock()
+		headSlots = append(headSlots, slot)
+		mu.Unlock()
+		err := s.PutBlockReward(&models.RewardBreakdown{Slot: slot, Mode: "light", Reward: slot})
+		indexed <- slot
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		ix.Follow(ctx)
+		close(done)
+	}()
+
+	// epoch 3 covers slots up to 96 only
+	require.Eventually(t, func() bool {
+		reward, err := s.GetBlockReward(100, "light")
+		return err == nil && reward != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	mu.Lock()
+	require.Equal(t, []int64{100, 101, 102, 103}, headSlots)
+	mu.Unlock()
+	for _, slot := range headSlots {
+		reward, err := s.GetBlockReward(slot, "light")
+		require.NoError(t, err)
+		require.False(t, reward.Finalized)
+	}
+	marked, err := s.MarkFinalized(4 * beaconadapter.EthereumSlotsPerEpoch)
+	require.NoError(t, err)
+	require.Equal(t, 4, marked)
+	reward, err := s.GetBlockReward(102, "light")
+	require.NoError(t, err)
+	require.True(t, reward.Finalized)
+}
+
+func TestIndexHeadDetectsReorg(t *testing.T) {
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+	// slot 101 was reorged out, the new block of slot 102 builds on 100
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/headers/101":
+			w.WriteHeader(http.StatusNotFound)
+		case "/eth/v1/beacon/headers/102":
+			fmt.Fprint(w, `{"execution_optimistic":false,"finalized":false,"data":{"root":"0xcc","canonical":true,`+
+				`"header":{"message":{"slot":"102","proposer_index":"1","parent_root":"0xaa"}}}}`)
+		default:
+			t.Errorf("unexpected request %v", r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer node.Close()
+	beaconClient, err := beaconadapter.NewBeaconClient(node.URL, nil)
+	require.NoError(t, err)
+	ix := &Indexer{store: s, mode: "light", beacon: beaconClient}
+	require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: 100, Mode: "light", BlockRoot: "0xaa"}))
+	require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: 101, Mode: "light", BlockRoot: "0xbb", ParentRoot: "0xaa"}))
+
+	var reorgErr *ReorgError
+	require.ErrorAs(t, ix.IndexHead(context.Background(), 102), &reorgErr)
+	require.Equal(t, int64(101), reorgErr.Slot)
+
+	// reindexing the reorged slot finds it missed now
+	require.NoError(t, ix.IndexHead(context.Background(), 101))
+	reward, err := s.GetBlockReward(101, "light")
+	require.NoError(t, err)
+	require.Nil(t, reward)
+}
+
+func TestFollowChainReorg(t *testing.T) {
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+
+	indexed := make(chan int64, 10)
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: head\ndata: {\"slot\":\"100\"}\n\n")
+		fmt.Fprint(w, "event: head\ndata: {\"slot\":\"102\"}\n\n")
+		w.(http.Flusher).Flush()
+		for slot := range indexed {
+			if slot == 102 {
+				break
+			}
+		}
+		fmt.Fprint(w, "event: chain_reorg\ndata: {\"slot\":\"102\",\"depth\":\"1\"}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer node.Close()
+
+	beaconClient, err := beaconadapter.NewBeaconClient(node.URL, nil)
+	require.NoError(t, err)
+	var mu sync.Mutex
+	var headSlots []int64
+	ix := &Indexer{store: s, mode: "light", beacon: beaconClient}
+	ix.indexHead = func(_ context.Context, slot int64) error {
+		mu.Lock()
+		headSlots = append(headSlots, slot)
+		mu.Unlock()
+		err := s.PutBlockReward(&models.RewardBreakdown{Slot: slot, Mode: "light"})
+		indexed <- slot
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		ix.Follow(ctx)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(headSlots) == 5
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	// the slots from the common ancestor on are computed again
+	require.Equal(t, []int64{100, 101, 102, 101, 102}, headSlots)
+}
+
+func TestFollowFinalizedFork(t *testing.T) {
+	s, err := store.Open(filepath.Join(t.TempDir(), "store.db"))
+	require.NoError(t, err)
+	defer s.Close()
+	// slot 96 was stored from a fork the finalized checkpoint doesn't build on
+	require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: 66, Mode: "light", BlockRoot: "0x66"}))
+	require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: 95, Mode: "light", BlockRoot: "0x95"}))
+	require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: 96, Mode: "light", BlockRoot: "0xaa"}))
+
+	indexed := make(chan int64, 64)
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		// a bad event is skipped without breaking the stream
+		fmt.Fprint(w, "event: head\ndata: {\"slot\":\"x\"}\n\n")
+		fmt.Fprint(w, "event: head\ndata: {\"slot\":\"100\"}\n\n")
+		w.(http.Flusher).Flush()
+		for slot := range indexed {
+			if slot == 100 {
+				break
+			}
+		}
+		fmt.Fprint(w, "event: finalized_checkpoint\ndata: {\"epoch\":\"3\",\"block\":\"0xbb\"}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer node.Close()
+
+	beaconClient, err := beaconadapter.NewBeaconClient(node.URL, nil)
+	require.NoError(t, err)
+	var mu sync.Mutex
+	var headSlots []int64
+	ix := &Indexer{store: s, mode: "light", beacon: beaconClient}
+	ix.indexHead = func(_ context.Context, slot int64) error {
+		mu.Lock()
+		headSlots = append(headSlots, slot)
+		mu.Unlock()
+		root := "0x00"
+		if slot == 96 {
+			root = "0xbb"
+		}
+		err := s.PutBlockReward(&models.RewardBreakdown{Slot: slot, Mode: "light", BlockRoot: root})
+		indexed <- slot
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		ix.Follow(ctx)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(headSlots) == 34
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	// the slots from one epoch before the checkpoint on are dropped, and computed again as far as the heads reach
+	mu.Lock()
+	require.Equal(t, int64(100), headSlots[0])
+	require.Equal(t, int64(68), headSlots[1])
+	mu.Unlock()
+	reward, err := s.GetBlockReward(66, "light")
+	require.NoError(t, err)
+	require.Nil(t, reward)
+	reward, err = s.GetBlockReward(95, "light")
+	require.NoError(t, err)
+	require.Equal(t, "0x00", reward.BlockRoot)
+	reward, err = s.GetBlockReward(96, "light")
+	require.NoError(t, err)
+	require.Equal(t, "0xbb", reward.BlockRoot)
+	require.False(t, reward.Finalized)
+
+	// the recomputed block matches the checkpoint
+	require.NoError(t, ix.finalize(3, "0xbb", make(chan int64)))
+	reward, err = s.GetBlockReward(96, "light")
+	require.NoError(t, err)
+	require.True(t, reward.Finalized)
+}
@@ -0,0 +1,124 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/rewards"
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/models"
+)
+
+type SlotStatus int
+
+const (
+	SlotIndexed SlotStatus = iota
+	SlotMissed
+	SlotSkipped
+)
+
+var (
+	ErrNotFinalized = errors.New("slot is not finalized yet")
+)
+
+// Indexer computes rewards and sync committees for slots and writes them to the store.
+type Indexer struct {
+	store   *store.Store
+	mode    string
+	beacon  *beaconadapter.BeaconClient
+	rewards *rewards.RewardsClient
+
+	// OnReward is called with every reward IndexHead stores, reorged slots included
+	OnReward func(reward *models.RewardBreakdown)
+
+	// indexSlot and indexHead are IndexSlot and IndexHead, replaced in tests to avoid the upstream calls
+	indexSlot func(ctx context.Context, slot int64) (SlotStatus, error)
+	indexHead func(ctx context.Context, slot int64) error
+}
+
+func NewIndexer(baseURL, ethScanAPIKey, mode string, s *store.Store) (*Indexer, error) {
+	beaconClient, err := beaconadapter.NewBeaconClient(baseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	rewardsClient, err := rewards.NewRewardsClient(baseURL, ethScanAPIKey)
+	if err != nil {
+		return nil, err
+	}
+	ix := &Indexer{
+		store:   s,
+		mode:    mode,
+		beacon:  beaconClient,
+		rewards: rewardsClient,
+	}
+	ix.indexSlot = ix.IndexSlot
+	ix.indexHead = ix.IndexHead
+	return ix, nil
+}
+
+// IndexSlot computes and stores the results for a single finalized slot: its reward, sync committee and withdrawals.
+// Slots that are already in the store are skipped, missed slots have nothing to store.
+func (ix *Indexer) IndexSlot(ctx context.Context, slot int64) (SlotStatus, error) {
+	reward, err := ix.store.GetBlockReward(slot, ix.mode)
+	if err != nil {
+		return 0, err
+	}
+	committee, err := ix.store.GetSyncCommittee(slot)
+	if err != nil {
+		return 0, err
+	}
+	withdrawals, err := ix.store.GetWithdrawals(slot)
+	if err != nil {
+		return 0, err
+	}
+	if reward != nil && committee != nil && withdrawals != nil {
+		return SlotSkipped, nil
+	}
+
+	blockResp, err := ix.beacon.FetchBlockResponse(slot)
+	if errors.Is(err, beaconadapter.ErrNotFound) {
+		return SlotMissed, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !blockResp.Finalized {
+		return 0, ErrNotFinalized
+	}
+	if reward == nil {
+		reward, err = ix.rewards.GetBlockRewardBreakdown(ctx, blockResp, ix.mode)
+		if err != nil {
+			return 0, fmt.Errorf("failed to compute the reward: %w", err)
+		}
+		if err := ix.store.PutBlockReward(reward); err != nil {
+			return 0, err
+		}
+	}
+	if withdrawals == nil {
+		withdrawals, err := rewards.BlockWithdrawals(blockResp)
+		if err != nil {
+			return 0, fmt.Errorf("failed to map the withdrawals: %w", err)
+		}
+		if err := rewards.ClassifyWithdrawals(ix.beacon, withdrawals.Withdrawals); err != nil {
+			return 0, fmt.Errorf("failed to classify the withdrawals: %w", err)
+		}
+		if err := ix.store.PutWithdrawals(withdrawals); err != nil {
+			return 0, err
+		}
+	}
+	if committee == nil {
+		committee, err := ix.rewards.GetSyncCommittee(slot)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch the sync committee: %w", err)
+		}
+		if !committee.Finalized {
+			return 0, ErrNotFinalized
+		}
+		if err := ix.store.PutSyncCommittee(slot, committee); err != nil {
+			return 0, err
+		}
+	}
+	return SlotIndexed, nil
+}
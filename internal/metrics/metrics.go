@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	constNamespace = "mewatcher"
+
+	UpstreamBeacon    = "beacon"
+	UpstreamBeaconcha = "beaconcha"
+	UpstreamEtherscan = "etherscan"
+	UpstreamExecution = "execution"
+	UpstreamPrices    = "prices"
+	CacheResultHit    = "hit"
+	CacheResultMiss   = "miss"
+	constStatusOK     = "ok"
+	constStatusError  = "error"
+)
+
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: constNamespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the API requests by route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	UpstreamRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: constNamespace,
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Latency of the calls to the beacon node, execution RPC and third party APIs.",
+		Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"upstream", "method", "status"})
+
+	UpstreamErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: constNamespace,
+		Name:      "upstream_errors_total",
+		Help:      "Failed upstream calls: transport errors and 5xx answers.",
+	}, []string{"upstream", "method"})
+
+	CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: constNamespace,
+		Name:      "cache_requests_total",
+		Help:      "Lookups in the store of finalized results by endpoint and result.",
+	}, []string{"cache", "result"})
+
+	WatchedBalance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: constNamespace,
+		Name:      "watched_validator_balance_gwei",
+		Help:      "Balance of the watched validators at the end of the last checked epoch.",
+	}, []string{"validator"})
+
+	WatchedProposalReward = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: constNamespace,
+		Name:      "watched_validator_last_proposal_reward_gwei",
+		Help:      "Reward of the last block proposed by the watched validators.",
+	}, []string{"validator"})
+
+	WatchedSyncParticipation = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: constNamespace,
+		Name:      "watched_validator_sync_participation_ratio",
+		Help:      "Share of the sync committee signatures made in the last checked epoch, for watched validators in the committee.",
+	}, []string{"validator"})
+)
+
+// ObserveUpstream records an upstream call that started at start. status is the HTTP status code,
+// 0 for calls that have none, like JSON-RPC ones, or failed before getting an answer.
+func ObserveUpstream(upstream, method string, start time.Time, status int, err error) {
+	statusLabel := strconv.Itoa(status)
+	if status == 0 {
+		statusLabel = constStatusOK
+		if err != nil {
+			statusLabel = constStatusError
+		}
+	}
+	UpstreamRequestDuration.WithLabelValues(upstream, method, statusLabel).Observe(time.Since(start).Seconds())
+	if err != nil || status >= 500 {
+		UpstreamErrors.WithLabelValues(upstream, method).Inc()
+	}
+}
+
+// ObserveCache records a store lookup for the given cache.
+func ObserveCache(cache string, hit bool) {
+	result := CacheResultMiss
+	if hit {
+		result = CacheResultHit
+	}
+	CacheRequests.WithLabelValues(cache, result).Inc()
+}
+
+// ValidatorLabel is the label value of a validator index.
+func ValidatorLabel(index int64) string {
+	return strconv.FormatInt(index, 10)
+}
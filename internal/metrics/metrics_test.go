@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserveUpstream(t *testing.T) {
+	start := time.Now()
+	ObserveUpstream(UpstreamBeacon, "FetchBlockHeader", start, 200, nil)
+	ObserveUpstream(UpstreamBeacon, "FetchBlockHeader", start, 404, nil)
+	ObserveUpstream(UpstreamBeacon, "FetchBlockHeader", start, 503, nil)
+	ObserveUpstream(UpstreamExecution, "eth_getBlockByNumber", start, 0, errors.New("connection refused"))
+	ObserveUpstream(UpstreamExecution, "eth_getBlockByNumber", start, 0, nil)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(UpstreamErrors.WithLabelValues(UpstreamBeacon, "FetchBlockHeader")))
+	require.Equal(t, 1.0, testutil.ToFloat64(UpstreamErrors.WithLabelValues(UpstreamExecution, "eth_getBlockByNumber")))
+	require.Equal(t, 5, testutil.CollectAndCount(UpstreamRequestDuration))
+}
+
+func TestObserveCache(t *testing.T) {
+	ObserveCache("blockreward", true)
+	ObserveCache("blockreward", true)
+	ObserveCache("blockreward", false)
+	require.Equal(t, 2.0, testutil.ToFloat64(CacheRequests.WithLabelValues("blockreward", CacheResultHit)))
+	require.Equal(t, 1.0, testutil.ToFloat64(CacheRequests.WithLabelValues("blockreward", CacheResultMiss)))
+}
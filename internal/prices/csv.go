@@ -0,0 +1,80 @@
+package prices
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVSource serves the prices of a CSV file with a date column, YYYY-MM-DD, and a column per currency:
+//
+//	date,usd,eur
+//	2024-05-01,3012.57,2815.10
+type CSVSource struct {
+	// prices are by currency then day
+	prices map[string]map[string]float64
+}
+
+func LoadCSV(file string) (*CSVSource, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the price file: %w", err)
+	}
+	defer f.Close()
+	return ReadCSV(f)
+}
+
+// ReadCSV reads the prices in the format of LoadCSV. Empty cells are days without a price.
+func ReadCSV(r io.Reader) (*CSVSource, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the price header: %w", err)
+	}
+	if len(header) < 2 || NormalizeCurrency(header[0]) != "date" {
+		return nil, errors.New("the price header must be date followed by the currencies")
+	}
+	source := &CSVSource{prices: make(map[string]map[string]float64, len(header)-1)}
+	currencies := make([]string, len(header))
+	for i, name := range header[1:] {
+		currencies[i+1] = NormalizeCurrency(name)
+		source.prices[currencies[i+1]] = make(map[string]float64)
+	}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return source, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the prices: %w", err)
+		}
+		day, err := time.Parse(constDateLayout, strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid price date %q: %w", record[0], err)
+		}
+		for i, cell := range record[1:] {
+			if cell = strings.TrimSpace(cell); cell == "" {
+				continue
+			}
+			price, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s price on %s: %w", currencies[i+1], record[0], err)
+			}
+			source.prices[currencies[i+1]][day.Format(constDateLayout)] = price
+		}
+	}
+}
+
+func (s *CSVSource) Price(_ context.Context, currency string, day time.Time) (float64, error) {
+	price, ok := s.prices[currency][day.UTC().Format(constDateLayout)]
+	if !ok {
+		return 0, ErrNoPrice
+	}
+	return price, nil
+}
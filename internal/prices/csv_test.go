@@ -0,0 +1,35 @@
+package prices
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	source, err := ReadCSV(strings.NewReader("date,USD,eur\n2024-05-01,3012.57,2815.10\n2024-05-02,2990,\n"))
+	require.NoError(t, err)
+	price, err := source.Price(context.Background(), "usd", time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.InDelta(t, 2990, price, 1e-9)
+	price, err = source.Price(context.Background(), "eur", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.InDelta(t, 2815.10, price, 1e-9)
+	// the empty cell and the days and currencies out of the file have no price
+	_, err = source.Price(context.Background(), "eur", time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, ErrNoPrice)
+	_, err = source.Price(context.Background(), "usd", time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, ErrNoPrice)
+	_, err = source.Price(context.Background(), "gbp", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, ErrNoPrice)
+
+	_, err = ReadCSV(strings.NewReader("day,usd\n2024-05-01,3012.57\n"))
+	require.Error(t, err)
+	_, err = ReadCSV(strings.NewReader("date,usd\n01/05/2024,3012.57\n"))
+	require.Error(t, err)
+	_, err = ReadCSV(strings.NewReader("date,usd\n2024-05-01,cheap\n"))
+	require.Error(t, err)
+}
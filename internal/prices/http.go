@@ -0,0 +1,125 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"ethereum-validator-api/internal/metrics"
+	"ethereum-validator-api/internal/tracing"
+)
+
+const (
+	constHistoryPath   = "/api/v3/coins/ethereum/history"
+	constHistoryMethod = "coins/history"
+	constHTTPTimeout   = 10 * time.Second
+)
+
+type historyResponse struct {
+	MarketData *struct {
+		CurrentPrice map[string]float64 `json:"current_price"`
+	} `json:"market_data"`
+}
+
+// HTTPSource asks a CoinGecko compatible API for the ETH price of a day, in all currencies at once.
+// The days are kept once fetched, the price of a past day doesn't change.
+type HTTPSource struct {
+	baseURL      string
+	apiKeyHeader string
+	apiKey       string
+	httpClient   *http.Client
+	// fetches merges the concurrent fetches of a day
+	fetches singleflight.Group
+
+	mu sync.Mutex
+	// days are the prices of the fetched days by currency
+	days map[string]map[string]float64
+}
+
+// NewHTTPSource creates a source for the API at baseURL. The API key, when set, is sent in the apiKeyHeader header.
+func NewHTTPSource(baseURL, apiKeyHeader, apiKey string, httpClient *http.Client) *HTTPSource {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: constHTTPTimeout}
+	}
+	return &HTTPSource{
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		apiKeyHeader: apiKeyHeader,
+		apiKey:       apiKey,
+		httpClient:   httpClient,
+		days:         make(map[string]map[string]float64),
+	}
+}
+
+func (s *HTTPSource) Price(ctx context.Context, currency string, day time.Time) (float64, error) {
+	date := day.UTC().Format(constDateLayout)
+	s.mu.Lock()
+	prices, ok := s.days[date]
+	s.mu.Unlock()
+	if !ok {
+		if day.After(time.Now()) {
+			return 0, ErrNoPrice
+		}
+		fetched, err, _ := s.fetches.Do(date, func() (any, error) {
+			prices, err := s.fetch(ctx, day.UTC())
+			// the price of the current day is still moving
+			if err == nil && date != time.Now().UTC().Format(constDateLayout) {
+				s.mu.Lock()
+				s.days[date] = prices
+				s.mu.Unlock()
+			}
+			return prices, err
+		})
+		if err != nil {
+			return 0, err
+		}
+		prices = fetched.(map[string]float64)
+	}
+	price, ok := prices[currency]
+	if !ok {
+		return 0, ErrNoPrice
+	}
+	return price, nil
+}
+
+func (s *HTTPSource) fetch(ctx context.Context, day time.Time) (map[string]float64, error) {
+	query := url.Values{"date": {day.Format("02-01-2006")}, "localization": {"false"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+constHistoryPath+"?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	if s.apiKey != "" {
+		req.Header.Set(s.apiKeyHeader, s.apiKey)
+	}
+	ctx, span := tracing.StartUpstream(ctx, metrics.UpstreamPrices, constHistoryMethod, req)
+	start := time.Now()
+	resp, err := s.httpClient.Do(req.WithContext(ctx))
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	metrics.ObserveUpstream(metrics.UpstreamPrices, constHistoryMethod, start, status, err)
+	tracing.End(span, status, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the prices: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch the prices: status %d", resp.StatusCode)
+	}
+	var history historyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&history); err != nil {
+		return nil, fmt.Errorf("failed to decode the prices: %w", err)
+	}
+	// the API answers without market data for the days before ETH was listed
+	if history.MarketData == nil {
+		return map[string]float64{}, nil
+	}
+	return history.MarketData.CurrentPrice, nil
+}
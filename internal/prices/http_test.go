@@ -0,0 +1,54 @@
+package prices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSource(t *testing.T) {
+	var calls atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, constHistoryPath, r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("x-cg-demo-api-key"))
+		switch r.URL.Query().Get("date") {
+		case "01-05-2024":
+			fmt.Fprint(w, `{"id":"ethereum","market_data":{"current_price":{"usd":3012.57,"eur":2815.1}}}`)
+		case "01-05-2014":
+			// before ETH was listed
+			fmt.Fprint(w, `{"id":"ethereum"}`)
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer api.Close()
+	source := NewHTTPSource(api.URL+"/", "x-cg-demo-api-key", "secret", nil)
+	ctx := context.Background()
+
+	price, err := source.Price(ctx, "eur", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.InDelta(t, 2815.1, price, 1e-9)
+	// the other currencies of the day are kept
+	price, err = source.Price(ctx, "usd", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.InDelta(t, 3012.57, price, 1e-9)
+	require.EqualValues(t, 1, calls.Load())
+
+	_, err = source.Price(ctx, "gbp", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, ErrNoPrice)
+	_, err = source.Price(ctx, "usd", time.Date(2014, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, ErrNoPrice)
+	_, err = source.Price(ctx, "usd", time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC))
+	require.ErrorContains(t, err, "status 429")
+	_, err = source.Price(ctx, "usd", time.Now().Add(48*time.Hour))
+	require.ErrorIs(t, err, ErrNoPrice)
+	require.EqualValues(t, 3, calls.Load())
+}
@@ -0,0 +1,76 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"ethereum-validator-api/models"
+)
+
+const (
+	SourceCSV  = "csv"
+	SourceHTTP = "http"
+
+	constDateLayout = "2006-01-02"
+	constGweiPerEth = 1e9
+)
+
+var ErrNoPrice = errors.New("no price")
+
+// Source gives the daily price of ETH in fiat currencies.
+type Source interface {
+	// Price is the price of one ETH in the currency on the UTC day of day.
+	// It returns ErrNoPrice when the source has none for the currency and day.
+	Price(ctx context.Context, currency string, day time.Time) (float64, error)
+}
+
+// Config selects and sets up the price source.
+type Config struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Source is csv or http
+	Source string `mapstructure:"source"`
+	// File is the CSV file of the csv source
+	File string `mapstructure:"file"`
+	// URL is the base URL of the CoinGecko compatible API of the http source
+	URL          string `mapstructure:"url"`
+	APIKey       string `mapstructure:"api_key"`
+	APIKeyHeader string `mapstructure:"api_key_header"`
+}
+
+// NewSource creates the configured source, nil when disabled.
+func NewSource(cfg Config) (Source, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	switch cfg.Source {
+	case SourceCSV:
+		return LoadCSV(cfg.File)
+	case SourceHTTP:
+		return NewHTTPSource(cfg.URL, cfg.APIKeyHeader, cfg.APIKey, nil), nil
+	}
+	return nil, fmt.Errorf("unknown price source %q, expected csv or http", cfg.Source)
+}
+
+// NormalizeCurrency is the currency code as the sources know it.
+func NormalizeCurrency(currency string) string {
+	return strings.ToLower(strings.TrimSpace(currency))
+}
+
+// Value values the amount in gwei in the currency at the ETH price of the day of at.
+func Value(ctx context.Context, source Source, currency string, at time.Time, gwei int64) (*models.FiatValue, error) {
+	currency = NormalizeCurrency(currency)
+	day := at.UTC().Truncate(24 * time.Hour)
+	price, err := source.Price(ctx, currency, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the %s price of %s: %w", currency, day.Format(constDateLayout), err)
+	}
+	return &models.FiatValue{
+		Currency: currency,
+		Date:     day.Format(constDateLayout),
+		Price:    price,
+		Reward:   float64(gwei) / constGweiPerEth * price,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package prices
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/models"
+)
+
+func TestValue(t *testing.T) {
+	source, err := ReadCSV(strings.NewReader("date,usd\n2024-05-01,3000\n"))
+	require.NoError(t, err)
+	// the price of the day of the block applies, whatever the time of the block
+	fiat, err := Value(context.Background(), source, " USD", time.Date(2024, 5, 1, 23, 59, 47, 0, time.UTC), 25_000_000)
+	require.NoError(t, err)
+	require.Equal(t, &models.FiatValue{Currency: "usd", Date: "2024-05-01", Price: 3000, Reward: 75}, fiat)
+	_, err = Value(context.Background(), source, "usd", time.Date(2024, 5, 2, 0, 0, 1, 0, time.UTC), 25_000_000)
+	require.ErrorIs(t, err, ErrNoPrice)
+}
+
+func TestNewSource(t *testing.T) {
+	source, err := NewSource(Config{Source: SourceHTTP})
+	require.NoError(t, err)
+	require.Nil(t, source)
+	_, err = NewSource(Config{Enabled: true, Source: "oracle"})
+	require.Error(t, err)
+	_, err = NewSource(Config{Enabled: true, Source: SourceCSV, File: "missing.csv"})
+	require.Error(t, err)
+}
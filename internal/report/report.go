@@ -0,0 +1,522 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/indexer"
+	"ethereum-validator-api/internal/prices"
+	"ethereum-validator-api/internal/rewards"
+	"ethereum-validator-api/internal/store"
+	"ethereum-validator-api/models"
+)
+
+const (
+	constDateLayout = "2006-01-02"
+	constGweiPerEth = 1e9
+	// constMergeSlot is the first slot with an execution payload, the blocks before paid the proposers nothing
+	// and had no withdrawals
+	constMergeSlot = 4700013
+)
+
+// Entry is the income of a validator on a UTC day, the amounts are in gwei.
+type Entry struct {
+	Date           string `json:"date"`
+	ValidatorIndex int64  `json:"validator_index"`
+	// ConsensusIncome is the change of the balance over the day, the withdrawals added back
+	ConsensusIncome int64 `json:"cl_income"`
+	// PriorityFees are the fees of the blocks the validator built itself, the burnt fees left out
+	PriorityFees   int64 `json:"priority_fees"`
+	MevPayments    int64 `json:"mev_payments"`
+	Withdrawals    int64 `json:"withdrawals"`
+	ProposedBlocks int64 `json:"proposed_blocks"`
+	EndBalance     int64 `json:"end_balance"`
+	// Fiat values the amounts at the ETH price of the day, only set when a currency is requested
+	Fiat *FiatAmounts `json:"fiat,omitempty"`
+}
+
+// FiatAmounts are the amounts of an entry or a total in a fiat currency.
+type FiatAmounts struct {
+	Currency string `json:"currency"`
+	// DailyPrice is the price of one ETH on the UTC day, the one every amount of the day is valued at
+	// whatever its time; totals span several days and have none
+	DailyPrice      float64 `json:"daily_price,omitempty"`
+	ConsensusIncome float64 `json:"cl_income"`
+	PriorityFees    float64 `json:"priority_fees"`
+	MevPayments     float64 `json:"mev_payments"`
+	Withdrawals     float64 `json:"withdrawals"`
+}
+
+// Totals sums up entries, the fiat amounts at the price of the day of each entry.
+type Totals struct {
+	ConsensusIncome int64        `json:"cl_income"`
+	PriorityFees    int64        `json:"priority_fees"`
+	MevPayments     int64        `json:"mev_payments"`
+	Withdrawals     int64        `json:"withdrawals"`
+	ProposedBlocks  int64        `json:"proposed_blocks"`
+	Fiat            *FiatAmounts `json:"fiat,omitempty"`
+}
+
+// ValidatorTotals are the totals of a single validator.
+type ValidatorTotals struct {
+	ValidatorIndex int64 `json:"validator_index"`
+	Totals
+}
+
+// Summary sums up the ledger of a report.
+type Summary struct {
+	Year int `json:"year"`
+	// From and To are the first and last day of the ledger, To is the last finalized day of the current year
+	From       string            `json:"from"`
+	To         string            `json:"to"`
+	Currency   string            `json:"currency,omitempty"`
+	Validators []int64           `json:"validators"`
+	Totals     Totals            `json:"totals"`
+	ByIndex    []ValidatorTotals `json:"by_validator"`
+}
+
+// Report is the daily ledger of the income of validators over a year.
+type Report struct {
+	// Entries are ordered by day and then by validator, days without income, withdrawal or balance have none
+	Entries []Entry
+	Summary Summary
+}
+
+// Reporter puts together the income of validators from the beacon states, their blocks and Etherscan.
+type Reporter struct {
+	beacon *beaconadapter.BeaconClient
+	prices prices.Source
+
+	// reward and withdrawals are Indexer.Reward and RewardsClient.Withdrawals, replaced in tests to avoid the upstream calls
+	reward      func(ctx context.Context, slot int64) (*models.RewardBreakdown, error)
+	withdrawals func(ctx context.Context, address string, fromBlock, toBlock int64) ([]rewards.Withdrawal, error)
+}
+
+// NewReporter creates a reporter reading the finalized rewards from the store when s isn't nil.
+// priceSource may be nil when no currency is requested.
+func NewReporter(baseURL, ethScanAPIKey, mode string, s *store.Store, priceSource prices.Source) (*Reporter, error) {
+	beaconClient, err := beaconadapter.NewBeaconClient(baseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	ix, err := indexer.NewIndexer(baseURL, ethScanAPIKey, mode, s)
+	if err != nil {
+		return nil, err
+	}
+	rewardsClient, err := rewards.NewRewardsClient(baseURL, ethScanAPIKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Reporter{
+		beacon:      beaconClient,
+		prices:      priceSource,
+		reward:      ix.Reward,
+		withdrawals: rewardsClient.Withdrawals,
+	}, nil
+}
+
+// ledger holds what the entries are put together from, the days are indexed as in firstSlots.
+type ledger struct {
+	// firstSlots are the first slots of the days, and of the day after the last one
+	firstSlots []int64
+	// balances are the balances at the end of the day before each day, and at the end of the last day
+	balances []map[int64]int64
+	// income is the block income and the withdrawals of the validators by day
+	income map[int64][]Entry
+}
+
+// Year puts together the ledger of the validators over the UTC days of the year that are finalized.
+// With a currency the amounts are valued at the ETH price of their day, a missing price fails the report.
+// The workers fetch the states, duties and blocks of the days at the same time.
+func (r *Reporter) Year(ctx context.Context, validators []int64, year int, currency string, workers int) (*Report, error) {
+	validators = unique(validators)
+	if len(validators) == 0 {
+		return nil, errors.New("no validators")
+	}
+	if currency != "" && r.prices == nil {
+		return nil, errors.New("a currency needs a price source")
+	}
+	currency = prices.NormalizeCurrency(currency)
+	beacon := r.beacon.WithContext(ctx)
+	workers = max(workers, 1)
+
+	days, err := r.days(beacon, year)
+	if err != nil {
+		return nil, err
+	}
+	l := &ledger{
+		firstSlots: make([]int64, len(days)),
+		balances:   make([]map[int64]int64, len(days)),
+		income:     make(map[int64][]Entry, len(validators)),
+	}
+	for i, day := range days {
+		l.firstSlots[i] = firstSlot(beacon, day)
+	}
+	for _, index := range validators {
+		l.income[index] = make([]Entry, len(days)-1)
+	}
+
+	addresses, err := r.loadBalances(ctx, beacon, l, validators, workers)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadBlocks(ctx, beacon, l, workers); err != nil {
+		return nil, err
+	}
+	if err := r.loadWithdrawals(ctx, beacon, l, addresses); err != nil {
+		return nil, err
+	}
+
+	report := &Report{Summary: Summary{
+		Year:       year,
+		From:       days[0].Format(constDateLayout),
+		To:         days[len(days)-2].Format(constDateLayout),
+		Currency:   currency,
+		Validators: validators,
+	}}
+	for day := range len(days) - 1 {
+		// the price is only needed on the days with entries
+		price := -1.0
+		for _, index := range validators {
+			entry, ok := l.entry(index, day)
+			if !ok {
+				continue
+			}
+			entry.Date = days[day].Format(constDateLayout)
+			if currency != "" {
+				if price < 0 {
+					if price, err = r.prices.Price(ctx, currency, days[day]); err != nil {
+						return nil, fmt.Errorf("failed to get the %s price of %s: %w", currency, entry.Date, err)
+					}
+				}
+				entry.Fiat = &FiatAmounts{
+					Currency:        currency,
+					DailyPrice:      price,
+					ConsensusIncome: value(entry.ConsensusIncome, price),
+					PriorityFees:    value(entry.PriorityFees, price),
+					MevPayments:     value(entry.MevPayments, price),
+					Withdrawals:     value(entry.Withdrawals, price),
+				}
+			}
+			report.Entries = append(report.Entries, entry)
+		}
+	}
+	report.Summary.Totals, report.Summary.ByIndex = sum(report.Entries, validators, currency)
+	return report, nil
+}
+
+// days returns the UTC days of the year that are finalized, followed by the day after the last one.
+func (r *Reporter) days(beacon *beaconadapter.BeaconClient, year int) ([]time.Time, error) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	genesisDay := beaconadapter.EthereumMainnetGenesisTime.Truncate(24 * time.Hour)
+	if !end.After(genesisDay) {
+		return nil, fmt.Errorf("the beacon chain started in %d", genesisDay.Year())
+	}
+	start = maxTime(start, genesisDay)
+	finalized, err := beacon.FinalizedSlot()
+	if err != nil {
+		return nil, err
+	}
+	days := []time.Time{start}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+		if firstSlot(beacon, next)-1 > finalized {
+			break
+		}
+		days = append(days, next)
+	}
+	if len(days) < 2 {
+		return nil, fmt.Errorf("no day of %d is finalized yet", year)
+	}
+	return days, nil
+}
+
+// loadBalances fetches the balances at the end of the days and returns the withdrawal addresses
+// of the validators at the end of the last day.
+func (r *Reporter) loadBalances(ctx context.Context, beacon *beaconadapter.BeaconClient, l *ledger,
+	validators []int64, workers int) ([]string, error) {
+	states := make([]*beaconadapter.ValidatorResponse, len(l.firstSlots))
+	group, _ := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for i, first := range l.firstSlots {
+		// the balances before genesis are unknown, the deposits at genesis are no income
+		if first == 0 {
+			continue
+		}
+		group.Go(func() error {
+			resp, err := beacon.PublicKeysByValidatorIDs(validators, first-1)
+			if err != nil {
+				return fmt.Errorf("failed to fetch the balances at slot %d: %w", first-1, err)
+			}
+			states[i] = resp
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	for i, state := range states {
+		l.balances[i] = make(map[int64]int64)
+		if state == nil {
+			continue
+		}
+		for _, validator := range state.Data {
+			index, err := strconv.ParseInt(validator.Index, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			balance, err := strconv.ParseInt(validator.Balance, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			l.balances[i][index] = balance
+		}
+	}
+
+	var addresses []string
+	seen := make(map[string]bool)
+	for _, validator := range states[len(states)-1].Data {
+		_, address, err := beaconadapter.ParseWithdrawalCredentials(validator.Validator.WithdrawalCredentials)
+		if err != nil {
+			return nil, err
+		}
+		if address != "" && !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses, nil
+}
+
+// loadBlocks adds the execution income of the blocks the validators proposed, missed slots earn nothing.
+func (r *Reporter) loadBlocks(ctx context.Context, beacon *beaconadapter.BeaconClient, l *ledger, workers int) error {
+	from := max(l.firstSlots[0], constMergeSlot)
+	to := l.firstSlots[len(l.firstSlots)-1] - 1
+	if from > to {
+		return nil
+	}
+
+	firstEpoch := from / beaconadapter.EthereumSlotsPerEpoch
+	duties := make([][]int64, to/beaconadapter.EthereumSlotsPerEpoch-firstEpoch+1)
+	group, _ := errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for i := range duties {
+		group.Go(func() error {
+			epoch := firstEpoch + int64(i)
+			resp, err := beacon.FetchProposerDuties(epoch)
+			if err != nil {
+				return fmt.Errorf("failed to fetch the proposers of epoch %d: %w", epoch, err)
+			}
+			for _, duty := range resp.Data {
+				index, err := strconv.ParseInt(duty.ValidatorIndex, 10, 64)
+				if err != nil {
+					return err
+				}
+				slot, err := strconv.ParseInt(duty.Slot, 10, 64)
+				if err != nil {
+					return err
+				}
+				if _, ok := l.income[index]; ok && slot >= from && slot <= to {
+					duties[i] = append(duties[i], slot)
+				}
+			}
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	var slots []int64
+	for _, epochSlots := range duties {
+		slots = append(slots, epochSlots...)
+	}
+	blocks := make([]*models.RewardBreakdown, len(slots))
+	group, _ = errgroup.WithContext(ctx)
+	group.SetLimit(workers)
+	for i, slot := range slots {
+		group.Go(func() error {
+			reward, err := r.reward(ctx, slot)
+			if errors.Is(err, beaconadapter.ErrNotFound) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get the reward of slot %d: %w", slot, err)
+			}
+			blocks[i] = reward
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		if block == nil {
+			continue
+		}
+		income, ok := l.income[block.ProposerIndex]
+		if !ok {
+			continue
+		}
+		entry := &income[l.day(block.Slot)]
+		// the priority fees of an MEV-boost block go to the builder, the validator only gets its payment
+		if block.Mev {
+			entry.MevPayments += block.MevPayment
+		} else {
+			entry.PriorityFees += block.TransactionFees - block.BurntFees
+		}
+		entry.ProposedBlocks++
+	}
+	return nil
+}
+
+// loadWithdrawals adds the withdrawals of the validators, looked up by their withdrawal addresses.
+func (r *Reporter) loadWithdrawals(ctx context.Context, beacon *beaconadapter.BeaconClient, l *ledger, addresses []string) error {
+	from := max(l.firstSlots[0], constMergeSlot)
+	to := l.firstSlots[len(l.firstSlots)-1] - 1
+	if len(addresses) == 0 || from > to {
+		return nil
+	}
+	fromBlock, toBlock, ok, err := blockRange(beacon, from, to)
+	if err != nil || !ok {
+		return err
+	}
+	for _, address := range addresses {
+		withdrawals, err := r.withdrawals(ctx, address, fromBlock, toBlock)
+		if err != nil {
+			return fmt.Errorf("failed to fetch the withdrawals to %s: %w", address, err)
+		}
+		for _, withdrawal := range withdrawals {
+			income, ok := l.income[withdrawal.ValidatorIndex]
+			if !ok {
+				continue
+			}
+			slot := beacon.MapTimestampToSlot(withdrawal.Time)
+			if slot < from || slot > to {
+				continue
+			}
+			income[l.day(slot)].Withdrawals += withdrawal.Amount
+		}
+	}
+	return nil
+}
+
+// blockRange returns execution block numbers covering the blocks of the slots from..to, reading the last
+// block of the range alone: there is at most one block per slot, so the first one is at most to-from blocks
+// before it. A missed slot has no withdrawals, so the range ends at the last block before the missed ones.
+func blockRange(beacon *beaconadapter.BeaconClient, from, to int64) (fromBlock, toBlock int64, ok bool, err error) {
+	for slot := to; slot >= from; slot-- {
+		blockResp, err := beacon.FetchBlockResponse(slot)
+		if errors.Is(err, beaconadapter.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, 0, false, fmt.Errorf("failed to fetch the block of slot %d: %w", slot, err)
+		}
+		number, err := strconv.ParseInt(blockResp.Data.Message.Body.ExecutionPayload.BlockNumber, 10, 64)
+		if err != nil {
+			return 0, 0, false, fmt.Errorf("failed to parse the block number of slot %d: %w", slot, err)
+		}
+		return max(number-(slot-from), 0), number, true, nil
+	}
+	return 0, 0, false, nil
+}
+
+// day is the day of the slot.
+func (l *ledger) day(slot int64) int {
+	return sort.Search(len(l.firstSlots)-1, func(i int) bool { return l.firstSlots[i+1] > slot })
+}
+
+// entry puts together the entry of the validator on the day, none when it had no balance and income.
+func (l *ledger) entry(index int64, day int) (Entry, bool) {
+	entry := l.income[index][day]
+	entry.ValidatorIndex = index
+	start, hasStart := l.balances[day][index]
+	end, hasEnd := l.balances[day+1][index]
+	entry.EndBalance = end
+	// the first deposit of a validator is no income
+	if hasStart && hasEnd {
+		entry.ConsensusIncome = end - start + entry.Withdrawals
+	}
+	empty := entry.ConsensusIncome == 0 && entry.PriorityFees == 0 && entry.MevPayments == 0 &&
+		entry.Withdrawals == 0 && entry.ProposedBlocks == 0 && entry.EndBalance == 0
+	return entry, !empty
+}
+
+// sum sums up the entries, in total and by validator.
+func sum(entries []Entry, validators []int64, currency string) (Totals, []ValidatorTotals) {
+	var totals Totals
+	byIndex := make([]ValidatorTotals, len(validators))
+	positions := make(map[int64]int, len(validators))
+	for i, index := range validators {
+		byIndex[i].ValidatorIndex = index
+		positions[index] = i
+	}
+	if currency != "" {
+		totals.Fiat = &FiatAmounts{Currency: currency}
+		for i := range byIndex {
+			byIndex[i].Fiat = &FiatAmounts{Currency: currency}
+		}
+	}
+	for _, entry := range entries {
+		totals.add(&entry)
+		byIndex[positions[entry.ValidatorIndex]].add(&entry)
+	}
+	return totals, byIndex
+}
+
+func (t *Totals) add(entry *Entry) {
+	t.ConsensusIncome += entry.ConsensusIncome
+	t.PriorityFees += entry.PriorityFees
+	t.MevPayments += entry.MevPayments
+	t.Withdrawals += entry.Withdrawals
+	t.ProposedBlocks += entry.ProposedBlocks
+	if t.Fiat != nil && entry.Fiat != nil {
+		t.Fiat.ConsensusIncome += entry.Fiat.ConsensusIncome
+		t.Fiat.PriorityFees += entry.Fiat.PriorityFees
+		t.Fiat.MevPayments += entry.Fiat.MevPayments
+		t.Fiat.Withdrawals += entry.Fiat.Withdrawals
+	}
+}
+
+// firstSlot is the first slot that starts at or after t.
+func firstSlot(beacon *beaconadapter.BeaconClient, t time.Time) int64 {
+	slot := beacon.MapTimestampToSlot(t)
+	if beacon.MapSlotToTimestamp(slot).Before(t) {
+		slot++
+	}
+	return max(slot, 0)
+}
+
+func value(gwei int64, price float64) float64 {
+	return float64(gwei) / constGweiPerEth * price
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func unique(indices []int64) []int64 {
+	seen := make(map[int64]bool, len(indices))
+	result := make([]int64, 0, len(indices))
+	for _, index := range indices {
+		if !seen[index] {
+			seen[index] = true
+			result = append(result, index)
+		}
+	}
+	return result
+}
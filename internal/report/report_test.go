@@ -0,0 +1,167 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/prices"
+	"ethereum-validator-api/internal/rewards"
+	"ethereum-validator-api/models"
+)
+
+type dailyPrices map[string]float64
+
+func (p dailyPrices) Price(_ context.Context, currency string, day time.Time) (float64, error) {
+	price, ok := p[currency+" "+day.Format(constDateLayout)]
+	if !ok {
+		return 0, prices.ErrNoPrice
+	}
+	return price, nil
+}
+
+func TestYear(t *testing.T) {
+	beacon, err := beaconadapter.NewBeaconClient("http://localhost", nil)
+	require.NoError(t, err)
+	day1 := firstSlot(beacon, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	day2 := firstSlot(beacon, time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC))
+	day3 := firstSlot(beacon, time.Date(2025, time.January, 3, 0, 0, 0, 0, time.UTC))
+	// January 3 isn't finalized yet
+	finalizedEpoch := (day3-1)/beaconadapter.EthereumSlotsPerEpoch + 1
+
+	// validator 7 withdraws to 0xaa..., validator 9 still has BLS credentials and deposits on January 1
+	balances := map[int64]map[int64]int64{
+		day1 - 1: {7: 32_000_000_000},
+		day2 - 1: {7: 32_010_000_000, 9: 32_000_000_000},
+		day3 - 1: {7: 32_015_000_000, 9: 32_003_000_000},
+	}
+	credentials := map[int64]string{
+		7: "0x010000000000000000000000aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		9: "0x00f50428677c60f997aadeab24aabf7fceaef491c96a52b463ae91f95611cf71",
+	}
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/finality_checkpoints"):
+			fmt.Fprintf(w, `{"data":{"finalized":{"epoch":"%d"}}}`, finalizedEpoch)
+		case strings.HasSuffix(r.URL.Path, "/validators"):
+			slot, _ := strconv.ParseInt(parts[len(parts)-2], 10, 64)
+			state, ok := balances[slot]
+			if !assert.True(t, ok, "state of slot %d", slot) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			resp := beaconadapter.ValidatorResponse{Finalized: true}
+			for _, index := range []int64{7, 9} {
+				if balance, ok := state[index]; ok {
+					var validator beaconadapter.ValidatorData
+					validator.Index = strconv.FormatInt(index, 10)
+					validator.Balance = strconv.FormatInt(balance, 10)
+					validator.Validator.WithdrawalCredentials = credentials[index]
+					resp.Data = append(resp.Data, validator)
+				}
+			}
+			//nolint:errcheck // That's expected
+			json.NewEncoder(w).Encode(resp)
+		case strings.Contains(r.URL.Path, "/duties/proposer/"):
+			epoch, _ := strconv.ParseInt(parts[len(parts)-1], 10, 64)
+			var duties []string
+			for slot, index := range map[int64]int64{day1 + 5: 7, day2 + 3: 9, day2 + 4: 8} {
+				if slot/beaconadapter.EthereumSlotsPerEpoch == epoch {
+					duties = append(duties, fmt.Sprintf(`{"validator_index":"%d","slot":"%d"}`, index, slot))
+				}
+			}
+			fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(duties, ","))
+		case strings.Contains(r.URL.Path, "/beacon/blocks/"):
+			// the first slot of the year was missed
+			slot, _ := strconv.ParseInt(parts[len(parts)-1], 10, 64)
+			if slot == day1 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprintf(w, `{"data":{"message":{"slot":"%d","body":{"execution_payload":{"block_number":"%d"}}}}}`, slot, slot+10_000_000)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer node.Close()
+
+	r, err := NewReporter(node.URL, "", "light", nil, dailyPrices{"eur 2025-01-01": 3000, "eur 2025-01-02": 3500})
+	require.NoError(t, err)
+	var (
+		mu       sync.Mutex
+		rewarded []int64
+	)
+	r.reward = func(_ context.Context, slot int64) (*models.RewardBreakdown, error) {
+		mu.Lock()
+		rewarded = append(rewarded, slot)
+		mu.Unlock()
+		switch slot {
+		case day1 + 5:
+			// the priority fees of the MEV-boost block went to the builder
+			return &models.RewardBreakdown{Slot: slot, ProposerIndex: 7, Mev: true,
+				TransactionFees: 3_000_000, BurntFees: 1_000_000, MevPayment: 50_000_000}, nil
+		case day2 + 3:
+			return &models.RewardBreakdown{Slot: slot, ProposerIndex: 9, TransactionFees: 5_000_000, BurntFees: 3_000_000}, nil
+		}
+		return nil, beaconadapter.ErrNotFound
+	}
+	r.withdrawals = func(_ context.Context, address string, fromBlock, toBlock int64) ([]rewards.Withdrawal, error) {
+		assert.Equal(t, "0xaAaAaAaaAaAaAaaAaAAAAAAAAaaaAaAaAaaAaaAa", address)
+		// the range is derived from the last block, the missed first slot of the year is covered too
+		assert.Equal(t, day1+10_000_000, fromBlock)
+		assert.Equal(t, day3-1+10_000_000, toBlock)
+		return []rewards.Withdrawal{
+			{Index: 1, ValidatorIndex: 7, Address: address, Amount: 5_000_000, Time: beacon.MapSlotToTimestamp(day2 + 10)},
+			// another validator with the same address
+			{Index: 2, ValidatorIndex: 8, Address: address, Amount: 4_000_000, Time: beacon.MapSlotToTimestamp(day2 + 11)},
+		}, nil
+	}
+
+	report, err := r.Year(context.Background(), []int64{9, 7, 9}, 2025, "EUR", 3)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{day1 + 5, day2 + 3}, rewarded)
+	assert.Equal(t, []Entry{
+		{Date: "2025-01-01", ValidatorIndex: 9, EndBalance: 32_000_000_000,
+			Fiat: &FiatAmounts{Currency: "eur", DailyPrice: 3000}},
+		{Date: "2025-01-01", ValidatorIndex: 7, ConsensusIncome: 10_000_000, MevPayments: 50_000_000,
+			ProposedBlocks: 1, EndBalance: 32_010_000_000,
+			Fiat: &FiatAmounts{Currency: "eur", DailyPrice: 3000, ConsensusIncome: 30, MevPayments: 150}},
+		{Date: "2025-01-02", ValidatorIndex: 9, ConsensusIncome: 3_000_000, PriorityFees: 2_000_000,
+			ProposedBlocks: 1, EndBalance: 32_003_000_000,
+			Fiat: &FiatAmounts{Currency: "eur", DailyPrice: 3500, ConsensusIncome: 10.5, PriorityFees: 7}},
+		{Date: "2025-01-02", ValidatorIndex: 7, ConsensusIncome: 10_000_000, Withdrawals: 5_000_000, EndBalance: 32_015_000_000,
+			Fiat: &FiatAmounts{Currency: "eur", DailyPrice: 3500, ConsensusIncome: 35, Withdrawals: 17.5}},
+	}, report.Entries)
+
+	summary := report.Summary
+	assert.Equal(t, "2025-01-01", summary.From)
+	assert.Equal(t, "2025-01-02", summary.To)
+	assert.Equal(t, []int64{9, 7}, summary.Validators)
+	assert.Equal(t, Totals{ConsensusIncome: 23_000_000, PriorityFees: 2_000_000, MevPayments: 50_000_000,
+		Withdrawals: 5_000_000, ProposedBlocks: 2,
+		Fiat: &FiatAmounts{Currency: "eur", ConsensusIncome: 75.5, PriorityFees: 7, MevPayments: 150, Withdrawals: 17.5}},
+		summary.Totals)
+	require.Len(t, summary.ByIndex, 2)
+	assert.Equal(t, int64(9), summary.ByIndex[0].ValidatorIndex)
+	assert.Equal(t, int64(3_000_000), summary.ByIndex[0].ConsensusIncome)
+	assert.Equal(t, int64(20_000_000), summary.ByIndex[1].ConsensusIncome)
+
+	// a missing price fails the report
+	_, err = r.Year(context.Background(), []int64{7}, 2025, "usd", 3)
+	require.ErrorIs(t, err, prices.ErrNoPrice)
+
+	_, err = r.Year(context.Background(), []int64{7}, 2019, "", 3)
+	require.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package rewards
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// batchCache shares the lookups between the blocks of a batch. The same builders pay the proposers
+// of many blocks in a row, so their MEV classification and the blocks it looks at are fetched once.
+// The receipts are left out, a transaction only ever belongs to the block being computed.
+type batchCache struct {
+	mevAddresses memo[string, bool]
+	blocks       memo[int64, *types.Block]
+}
+
+// memo runs a lookup once per key, the concurrent callers of the same key wait for the first one.
+// Failed lookups are forgotten so the next caller retries them.
+type memo[K comparable, T any] struct {
+	mu      sync.Mutex
+	lookups map[K]*lookup[T]
+}
+
+type lookup[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func (m *memo[K, T]) get(key K, fetch func() (T, error)) (T, error) {
+	m.mu.Lock()
+	if m.lookups == nil {
+		m.lookups = make(map[K]*lookup[T])
+	}
+	if l, ok := m.lookups[key]; ok {
+		m.mu.Unlock()
+		<-l.done
+		return l.value, l.err
+	}
+	l := &lookup[T]{done: make(chan struct{})}
+	m.lookups[key] = l
+	m.mu.Unlock()
+
+	l.value, l.err = fetch()
+	if l.err != nil {
+		m.mu.Lock()
+		delete(m.lookups, key)
+		m.mu.Unlock()
+	}
+	close(l.done)
+	return l.value, l.err
+}
+
+// ForBatch returns a client sharing its lookups between the blocks it computes, for the rewards
+// of a batch of slots computed concurrently. It is meant to be dropped with the batch.
+func (rc *RewardsClient) ForBatch() *RewardsClient {
+	batch := *rc
+	batch.cache = &batchCache{}
+	return &batch
+}
+
+// isMevAddressCached is isMevAdress, shared within a batch.
+func (rc *RewardsClient) isMevAddressCached(ctx context.Context, address string) (bool, error) {
+	if rc.cache == nil {
+		return rc.isMevAdress(ctx, address)
+	}
+	return rc.cache.mevAddresses.get(address, func() (bool, error) {
+		return rc.isMevAdress(ctx, address)
+	})
+}
+
+// blockByNumberCached is blockByNumber, shared within a batch.
+func (rc *RewardsClient) blockByNumberCached(ctx context.Context, height *big.Int) (*types.Block, error) {
+	if rc.cache == nil {
+		return rc.blockByNumber(ctx, height)
+	}
+	return rc.cache.blocks.get(height.Int64(), func() (*types.Block, error) {
+		return rc.blockByNumber(ctx, height)
+	})
+}
@@ -0,0 +1,48 @@
+package rewards
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemo(t *testing.T) {
+	var m memo[string, bool]
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := m.get("0xbuilder", func() (bool, error) {
+				fetches.Add(1)
+				<-release
+				return true, nil
+			})
+			require.NoError(t, err)
+			require.True(t, value)
+		}()
+	}
+	close(release)
+	wg.Wait()
+	require.EqualValues(t, 1, fetches.Load())
+
+	// the failures aren't kept
+	_, err := m.get("0xflaky", func() (bool, error) { return false, errors.New("rate limited") })
+	require.Error(t, err)
+	value, err := m.get("0xflaky", func() (bool, error) { return true, nil })
+	require.NoError(t, err)
+	require.True(t, value)
+}
+
+func TestForBatch(t *testing.T) {
+	rc := &RewardsClient{}
+	batch := rc.ForBatch()
+	require.Nil(t, rc.cache)
+	require.NotNil(t, batch.cache)
+	require.NotSame(t, batch.cache, rc.ForBatch().cache)
+}
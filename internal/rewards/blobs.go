@@ -0,0 +1,120 @@
+package rewards
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"ethereum-validator-api/internal/beaconadapter"
+)
+
+const (
+	// GasPerBlob is GAS_PER_BLOB from EIP-4844
+	GasPerBlob = 1 << 17
+	// constMinBlobBaseFee is MIN_BASE_FEE_PER_BLOB_GAS from EIP-4844, in wei
+	constMinBlobBaseFee = 1
+	// constBlobCommitmentVersion is VERSIONED_HASH_VERSION_KZG from EIP-4844
+	constBlobCommitmentVersion = 0x01
+)
+
+// blobSchedule is the BLOB_BASE_FEE_UPDATE_FRACTION of the mainnet forks that changed it, from their first epoch on
+var blobSchedule = []struct {
+	epoch          int64
+	updateFraction int64
+}{
+	// Deneb
+	{epoch: 269568, updateFraction: 3338477},
+	// Electra
+	{epoch: 364032, updateFraction: 5007716},
+	// the blob parameter only forks after Fulu
+	{epoch: 412672, updateFraction: 8346193},
+	{epoch: 419072, updateFraction: 11684671},
+}
+
+// BlobFees is the blob accounting of a block.
+type BlobFees struct {
+	Blobs       int64
+	BlobGasUsed int64
+	// BaseFee is the price of the blob gas in wei
+	BaseFee *big.Int
+	// Burnt is BlobGasUsed * BaseFee in wei, the blob fees are burnt entirely
+	Burnt *big.Int
+}
+
+// BurntGwei is Burnt in gwei.
+func (f *BlobFees) BurntGwei() int64 {
+	return weiToGwei(f.Burnt)
+}
+
+// BlockBlobFees computes the blob accounting of the block, nil for the blocks before Deneb.
+func BlockBlobFees(blockResp *beaconadapter.BlockResponse) (*BlobFees, error) {
+	message := &blockResp.Data.Message
+	slot, err := strconv.ParseInt(message.Slot, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	payload := &message.Body.ExecutionPayload
+	if payload.ExcessBlobGas == "" {
+		return nil, nil
+	}
+	excessBlobGas, err := strconv.ParseUint(payload.ExcessBlobGas, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the excess blob gas: %w", err)
+	}
+	blobGasUsed, err := strconv.ParseInt(payload.BlobGasUsed, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the blob gas used: %w", err)
+	}
+	baseFee := BlobBaseFee(slot, excessBlobGas)
+	if baseFee == nil {
+		return nil, nil
+	}
+	return &BlobFees{
+		Blobs:       int64(len(message.Body.BlobKzgCommitments)),
+		BlobGasUsed: blobGasUsed,
+		BaseFee:     baseFee,
+		Burnt:       new(big.Int).Mul(baseFee, big.NewInt(blobGasUsed)),
+	}, nil
+}
+
+// BlobBaseFee is the base fee per blob gas in wei of a block of the slot with the excess blob gas
+// of its header, as get_base_fee_per_blob_gas of EIP-4844. The slots before Deneb have none.
+func BlobBaseFee(slot int64, excessBlobGas uint64) *big.Int {
+	epoch := slot / beaconadapter.EthereumSlotsPerEpoch
+	var updateFraction int64
+	for _, fork := range blobSchedule {
+		if epoch >= fork.epoch {
+			updateFraction = fork.updateFraction
+		}
+	}
+	if updateFraction == 0 {
+		return nil
+	}
+	return fakeExponential(big.NewInt(constMinBlobBaseFee), new(big.Int).SetUint64(excessBlobGas), big.NewInt(updateFraction))
+}
+
+// fakeExponential approximates factor * e ** (numerator / denominator) with integers, as fake_exponential of EIP-4844.
+func fakeExponential(factor, numerator, denominator *big.Int) *big.Int {
+	output := new(big.Int)
+	accum := new(big.Int).Mul(factor, denominator)
+	for i := int64(1); accum.Sign() > 0; i++ {
+		output.Add(output, accum)
+		accum.Mul(accum, numerator)
+		accum.Div(accum, new(big.Int).Mul(denominator, big.NewInt(i)))
+	}
+	return output.Div(output, denominator)
+}
+
+// VersionedHash is the versioned hash of a blob KZG commitment, as the blob transactions reference it.
+func VersionedHash(commitment string) (string, error) {
+	raw := common.FromHex(commitment)
+	if len(raw) != 48 {
+		return "", fmt.Errorf("unexpected KZG commitment %q", commitment)
+	}
+	hash := sha256.Sum256(raw)
+	hash[0] = constBlobCommitmentVersion
+	return common.Hash(hash).Hex(), nil
+}
@@ -0,0 +1,55 @@
+package rewards
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/beaconadapter"
+)
+
+func TestBlobBaseFee(t *testing.T) {
+	const denebSlot, electraSlot = 269568 * 32, 364032 * 32
+	// the blob base fee only exists from Deneb on
+	require.Nil(t, BlobBaseFee(denebSlot-1, 0))
+	// without excess blob gas the fee is the minimum
+	require.Equal(t, big.NewInt(1), BlobBaseFee(denebSlot, 0))
+	// e ** 10 once the excess is ten update fractions
+	require.Equal(t, big.NewInt(22026), BlobBaseFee(denebSlot, 10*3338477))
+	require.Equal(t, big.NewInt(22026), BlobBaseFee(electraSlot, 10*5007716))
+	// Electra raised the update fraction, the same excess costs less
+	require.Equal(t, -1, BlobBaseFee(electraSlot, 10*3338477).Cmp(BlobBaseFee(electraSlot-1, 10*3338477)))
+}
+
+func TestBlockBlobFees(t *testing.T) {
+	var blockResp beaconadapter.BlockResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"data":{"message":{"slot":"8626176","body":{
+		"execution_payload":{"blob_gas_used":"262144","excess_blob_gas":"33384770"},
+		"blob_kzg_commitments":["0xc0","0xc0"]}}}}`), &blockResp))
+	fees, err := BlockBlobFees(&blockResp)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), fees.Blobs)
+	require.Equal(t, int64(2*GasPerBlob), fees.BlobGasUsed)
+	require.Equal(t, big.NewInt(22026), fees.BaseFee)
+	require.Equal(t, big.NewInt(22026*2*GasPerBlob), fees.Burnt)
+	require.Equal(t, int64(5), fees.BurntGwei())
+
+	// the payloads before Deneb have no blob gas
+	require.NoError(t, json.Unmarshal([]byte(`{"data":{"message":{"slot":"8000000","body":{"execution_payload":{}}}}}`), &blockResp))
+	fees, err = BlockBlobFees(&blockResp)
+	require.NoError(t, err)
+	require.Nil(t, fees)
+}
+
+func TestVersionedHash(t *testing.T) {
+	// the commitment of the point at infinity
+	hash, err := VersionedHash("0xc0" + strings.Repeat("00", 47))
+	require.NoError(t, err)
+	require.Equal(t, "0x010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c444014", hash)
+
+	_, err = VersionedHash("0xc0")
+	require.Error(t, err)
+}
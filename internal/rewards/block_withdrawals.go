@@ -0,0 +1,133 @@
+package rewards
+
+import (
+	"fmt"
+	"strconv"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/models"
+)
+
+const (
+	WithdrawalPartial = "partial"
+	WithdrawalFull    = "full"
+)
+
+// BlockWithdrawals maps the withdrawals of the block, their kind is left to ClassifyWithdrawals.
+// The blocks before Capella have none.
+func BlockWithdrawals(blockResp *beaconadapter.BlockResponse) (*models.SlotWithdrawals, error) {
+	message := &blockResp.Data.Message
+	slot, err := strconv.ParseInt(message.Slot, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.SlotWithdrawals{
+		Slot:                slot,
+		Withdrawals:         make([]models.Withdrawal, 0, len(message.Body.ExecutionPayload.Withdrawals)),
+		ExecutionOptimistic: blockResp.ExecutionOptimistic,
+		Finalized:           blockResp.Finalized,
+	}
+	// the blocks before the merge have no execution payload
+	if number := message.Body.ExecutionPayload.BlockNumber; number != "" {
+		if result.BlockNumber, err = strconv.ParseInt(number, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	for _, w := range message.Body.ExecutionPayload.Withdrawals {
+		withdrawal := models.Withdrawal{Slot: slot, Address: w.Address}
+		for _, field := range []struct {
+			value string
+			into  *int64
+		}{
+			{w.Index, &withdrawal.Index},
+			{w.ValidatorIndex, &withdrawal.ValidatorIndex},
+			{w.Amount, &withdrawal.Amount},
+		} {
+			if *field.into, err = strconv.ParseInt(field.value, 10, 64); err != nil {
+				return nil, fmt.Errorf("failed to parse withdrawal %s: %w", w.Index, err)
+			}
+		}
+		result.Withdrawals = append(result.Withdrawals, withdrawal)
+	}
+	return result, nil
+}
+
+// ClassifyWithdrawals sets the kind of the withdrawals: a withdrawal is full when it sweeps the whole balance
+// of a validator that became withdrawable, and partial otherwise. The withdrawable epoch of a validator is set
+// when its exit is initiated, long before it's reached, and never changes then, so the validators are read once
+// at the last slot of the withdrawals. A withdrawable validator is swept again when a deposit tops it up, so only
+// the withdrawal of at least the balance it had when it became withdrawable is the full one: that balance doesn't
+// change afterwards but for the deposits.
+func ClassifyWithdrawals(client *beaconadapter.BeaconClient, withdrawals []models.Withdrawal) error {
+	if len(withdrawals) == 0 {
+		return nil
+	}
+	indices := make([]int64, 0, len(withdrawals))
+	lastSlot := int64(0)
+	for _, withdrawal := range withdrawals {
+		indices = append(indices, withdrawal.ValidatorIndex)
+		lastSlot = max(lastSlot, withdrawal.Slot)
+	}
+	validatorResp, err := client.PublicKeysByValidatorIDs(indices, lastSlot)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the withdrawing validators: %w", err)
+	}
+	withdrawableEpochs := make(map[int64]int64, len(validatorResp.Data))
+	for _, validator := range validatorResp.Data {
+		index, err := strconv.ParseInt(validator.Index, 10, 64)
+		if err != nil {
+			return err
+		}
+		// FAR_FUTURE_EPOCH doesn't fit in an int64, the validator didn't exit
+		epoch, err := strconv.ParseUint(validator.Validator.WithdrawableEpoch, 10, 64)
+		if err != nil {
+			return err
+		}
+		withdrawableEpochs[index] = int64(min(epoch, uint64(1<<63-1)))
+	}
+
+	// the validators withdrawable at the slot of their withdrawal, by withdrawable epoch
+	exited := make(map[int64][]int64)
+	for _, withdrawal := range withdrawals {
+		withdrawableEpoch, ok := withdrawableEpochs[withdrawal.ValidatorIndex]
+		if !ok {
+			return fmt.Errorf("validator %d of withdrawal %d not found", withdrawal.ValidatorIndex, withdrawal.Index)
+		}
+		if withdrawableEpoch <= withdrawal.Slot/beaconadapter.EthereumSlotsPerEpoch {
+			exited[withdrawableEpoch] = append(exited[withdrawableEpoch], withdrawal.ValidatorIndex)
+		}
+	}
+	exitBalances := make(map[int64]int64)
+	for epoch, indices := range exited {
+		// no sweep of the validators can happen before the first slot of the epoch
+		validatorResp, err := client.PublicKeysByValidatorIDs(indices, max(epoch*beaconadapter.EthereumSlotsPerEpoch-1, 0))
+		if err != nil {
+			return fmt.Errorf("failed to fetch the balances of the validators withdrawable at epoch %d: %w", epoch, err)
+		}
+		for _, validator := range validatorResp.Data {
+			index, err := strconv.ParseInt(validator.Index, 10, 64)
+			if err != nil {
+				return err
+			}
+			if exitBalances[index], err = strconv.ParseInt(validator.Balance, 10, 64); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range withdrawals {
+		withdrawal := &withdrawals[i]
+		withdrawal.Kind = WithdrawalPartial
+		if withdrawableEpochs[withdrawal.ValidatorIndex] > withdrawal.Slot/beaconadapter.EthereumSlotsPerEpoch {
+			continue
+		}
+		exitBalance, ok := exitBalances[withdrawal.ValidatorIndex]
+		if !ok {
+			return fmt.Errorf("validator %d of withdrawal %d not found", withdrawal.ValidatorIndex, withdrawal.Index)
+		}
+		if withdrawal.Amount >= exitBalance {
+			withdrawal.Kind = WithdrawalFull
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package rewards
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/models"
+)
+
+func TestClassifyWithdrawals(t *testing.T) {
+	// validator 8 is withdrawable from epoch 2 on with 32 ETH, and was topped up by 1 ETH after its exit
+	var (
+		mu     sync.Mutex
+		states []string
+	)
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		mu.Lock()
+		states = append(states, parts[len(parts)-2])
+		mu.Unlock()
+		if parts[len(parts)-2] == "63" {
+			fmt.Fprint(w, `{"data":[{"index":"8","balance":"32000000000","validator":{"withdrawable_epoch":"2"}}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"index":"7","balance":"32017000000","validator":{"withdrawable_epoch":"18446744073709551615"}},`+
+			`{"index":"8","balance":"0","validator":{"withdrawable_epoch":"2"}}]}`)
+	}))
+	defer node.Close()
+	client, err := beaconadapter.NewBeaconClient(node.URL, nil)
+	require.NoError(t, err)
+
+	withdrawals := []models.Withdrawal{
+		{Index: 1, Slot: 40, ValidatorIndex: 7, Amount: 17_000_000},
+		{Index: 2, Slot: 100, ValidatorIndex: 8, Amount: 32_000_000_000},
+		{Index: 3, Slot: 5000, ValidatorIndex: 8, Amount: 1_000_000_000},
+	}
+	require.NoError(t, ClassifyWithdrawals(client, withdrawals))
+	kinds := make([]string, 0, len(withdrawals))
+	for _, withdrawal := range withdrawals {
+		kinds = append(kinds, withdrawal.Kind)
+	}
+	// the second sweep of the exited validator is the top-up, not its exit
+	require.Equal(t, []string{WithdrawalPartial, WithdrawalFull, WithdrawalPartial}, kinds)
+	// the validators at the last slot, and the balance of validator 8 before its withdrawable epoch
+	require.Equal(t, []string{"5000", "63"}, states)
+}
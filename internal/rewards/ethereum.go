@@ -3,23 +3,66 @@ package rewards
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"strconv"
 	"time"
 
 	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/metrics"
+	"ethereum-validator-api/internal/tracing"
 	"ethereum-validator-api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	ModeLight = "light"
+	ModeBeast = "beast"
+	// ModeFull is the name of the beast mode in the API
+	ModeFull = "full"
 )
 
 var (
 	ErrSlotNotFound = errors.New("slot not found")
+	ErrUnknownMode  = errors.New("unknown mode")
 )
 
 type RewardsClient struct {
 	client       *ethclient.Client
 	ethScan      *ethScanHelper
 	beaconClient *beaconadapter.BeaconClient
+	// cache is shared by the blocks of a batch, nil outside of one
+	cache *batchCache
+}
+
+// NormalizeMode maps a configured mode to one of the modes, anything but beast or full is light.
+func NormalizeMode(mode string) string {
+	if mode == ModeBeast || mode == ModeFull {
+		return ModeBeast
+	}
+	return ModeLight
+}
+
+// ParseMode maps a requested mode to one of the modes, unlike NormalizeMode it fails on unknown modes.
+func ParseMode(mode string) (string, error) {
+	switch mode {
+	case ModeLight:
+		return ModeLight, nil
+	case ModeFull, ModeBeast:
+		return ModeBeast, nil
+	}
+	return "", fmt.Errorf("%w %q, expected light or full", ErrUnknownMode, mode)
+}
+
+// APIMode is the name of the mode in the API.
+func APIMode(mode string) string {
+	if mode == ModeBeast {
+		return ModeFull
+	}
+	return ModeLight
 }
 
 func TimestampToSlot(timestamp time.Time) int64 {
@@ -33,96 +76,186 @@ func TimestampToSlot(timestamp time.Time) int64 {
 //}
 
 func (rc *RewardsClient) GetBlockRewardLight(ctx context.Context, height int64) (*models.BlockReward, error) {
-	block, err := rc.client.BlockByNumber(ctx, big.NewInt(height))
-	if err != nil {
-		return nil, ErrSlotNotFound
-	}
-	l := len(block.Transactions())
-	lastTx := block.Transactions()[l-1]
-	isMev, err := rc.isMevAdress(lastTx.To().String())
+	breakdown, err := rc.executionRewards(ctx, height)
 	if err != nil {
 		return nil, err
 	}
+	return breakdown.BlockReward(), nil
+}
 
-	transactionFees, err := rc.calculateTransactionFees(block)
+func (rc *RewardsClient) GetBlockRewardFull(ctx context.Context, slotno int64) (*models.BlockReward, error) {
+	blockResponse, err := rc.beaconClient.WithContext(ctx).FetchBlockResponse(slotno)
 	if err != nil {
-		return nil, errors.New("failed to calculate transaction fees")
+		return nil, err
 	}
-	burntFees := rc.calculateBurntFees(block)
-	transactionFees.Sub(transactionFees, burntFees)
-	if isMev {
-		transactionFees.Add(transactionFees, lastTx.Value())
+	breakdown, err := rc.GetBlockRewardBreakdown(ctx, blockResponse, ModeBeast)
+	if err != nil {
+		return nil, err
 	}
-	transactionFees.Div(transactionFees, big.NewInt(1e9))
-	return &models.BlockReward{
-		Status: isMev,
-		Reward: transactionFees.Int64(),
-	}, nil
+	return breakdown.BlockReward(), nil
 }
 
-func (rc *RewardsClient) GetBlockRewardFull(ctx context.Context, slotno int64) (*models.BlockReward, error) {
-	blockResponse, err := rc.beaconClient.FetchBlockResponse(slotno)
+// GetBlockRewardBreakdown computes the reward for an already fetched block.
+// The light mode only counts EL rewards, the beast mode adds the CL rewards of the proposer.
+func (rc *RewardsClient) GetBlockRewardBreakdown(ctx context.Context, blockResponse *beaconadapter.BlockResponse, mode string) (*models.RewardBreakdown, error) {
+	message := &blockResponse.Data.Message
+	slotno, err := strconv.ParseInt(message.Slot, 10, 64)
 	if err != nil {
 		return nil, err
 	}
-	blockno, err := strconv.ParseInt(blockResponse.Data.Message.Body.ExecutionPayload.BlockNumber, 10, 64)
+	blockno, err := strconv.ParseInt(message.Body.ExecutionPayload.BlockNumber, 10, 64)
 	if err != nil {
 		return nil, err
 	}
-	block, err := rc.client.BlockByNumber(ctx, big.NewInt(blockno))
+	proposerIndex, err := strconv.ParseInt(message.ProposerIndex, 10, 64)
 	if err != nil {
-		return nil, ErrSlotNotFound
+		return nil, err
+	}
+	breakdown, err := rc.executionRewards(ctx, blockno)
+	if err != nil {
+		return nil, err
 	}
+	breakdown.Slot = slotno
+	breakdown.ParentRoot = message.ParentRoot
+	breakdown.ProposerIndex = proposerIndex
+	breakdown.FeeRecipient = message.Body.ExecutionPayload.FeeRecipient
+	blobFees, err := BlockBlobFees(blockResponse)
+	if err != nil {
+		return nil, err
+	}
+	if blobFees != nil {
+		breakdown.Blobs = blobFees.Blobs
+		breakdown.BlobGasUsed = blobFees.BlobGasUsed
+		breakdown.BlobBaseFee = blobFees.BaseFee.Int64()
+		breakdown.BlobFees = blobFees.BurntGwei()
+	}
+	breakdown.Finalized = blockResponse.Finalized
+	breakdown.ExecutionOptimistic = blockResponse.ExecutionOptimistic
+	if mode == ModeBeast {
+		breakdown.Mode = ModeBeast
+		breakdown.ConsensusRewards = rc.consensusRewards(ctx, slotno, proposerIndex)
+		breakdown.Reward += breakdown.ConsensusRewards
+	}
+	computedAt := time.Now().UTC()
+	breakdown.ComputedAt = &computedAt
+	return breakdown, nil
+}
 
-	l := len(block.Transactions())
-	lastTx := block.Transactions()[l-1]
-	isMev, err := rc.isMevAdress(lastTx.To().String())
+// executionRewards computes the EL part of the reward: tx fees - burnt fees + mev (if block is mev)
+func (rc *RewardsClient) executionRewards(ctx context.Context, height int64) (*models.RewardBreakdown, error) {
+	block, err := rc.fetchBlock(ctx, height)
+	if err != nil {
+		return nil, ErrSlotNotFound
+	}
+	isMev, mevPayment, err := rc.mevPayment(ctx, block)
 	if err != nil {
 		return nil, err
 	}
 
-	transactionFees, err := rc.calculateTransactionFees(block)
+	transactionFees, err := rc.calculateTransactionFees(ctx, block)
 	if err != nil {
 		return nil, errors.New("failed to calculate transaction fees")
 	}
 	burntFees := rc.calculateBurntFees(block)
-	transactionFees.Sub(transactionFees, burntFees)
+	reward := new(big.Int).Sub(transactionFees, burntFees)
+	reward.Add(reward, mevPayment)
+	reward.Div(reward, big.NewInt(1e9))
+	return &models.RewardBreakdown{
+		BlockNumber:     height,
+		Mode:            ModeLight,
+		Mev:             isMev,
+		TransactionFees: weiToGwei(transactionFees),
+		BurntFees:       weiToGwei(burntFees),
+		MevPayment:      weiToGwei(mevPayment),
+		Reward:          reward.Int64(),
+	}, nil
+}
+
+func (rc *RewardsClient) fetchBlock(ctx context.Context, height int64) (*types.Block, error) {
+	ctx, span := tracing.Start(ctx, "rewards.fetch_block", attribute.Int64("block_number", height))
+	block, err := rc.blockByNumberCached(ctx, big.NewInt(height))
+	tracing.End(span, 0, err)
+	return block, err
+}
+
+// mevPayment classifies the block: the last transaction of a MEV block pays the proposer.
+func (rc *RewardsClient) mevPayment(ctx context.Context, block *types.Block) (isMev bool, payment *big.Int, err error) {
+	ctx, span := tracing.Start(ctx, "rewards.mev_classification")
+	defer func() {
+		span.SetAttributes(attribute.Bool("mev", isMev))
+		tracing.End(span, 0, err)
+	}()
+	payment = new(big.Int)
+	l := len(block.Transactions())
+	if l == 0 {
+		return false, payment, nil
+	}
+	lastTx := block.Transactions()[l-1]
+	// contract creations have no recipient and can't be a MEV payment
+	if lastTx.To() != nil {
+		isMev, err = rc.isMevAddressCached(ctx, lastTx.To().String())
+		if err != nil {
+			return false, nil, err
+		}
+	}
 	if isMev {
-		transactionFees.Add(transactionFees, lastTx.Value())
+		payment = lastTx.Value()
 	}
-	transactionFees.Div(transactionFees, big.NewInt(1e9))
+	return isMev, payment, nil
+}
 
-	// CL rewards section
-	proposerIndex, err := strconv.ParseInt(blockResponse.Data.Message.ProposerIndex, 10, 64)
-	if err != nil {
-		return nil, err
-	}
-	//nolint:ineffassign // That's expected
-	syncCommittee, _ := rc.beaconClient.FetchSyncDuties(slotno)
-	//nolint:ineffassign // That's expected
-	blockRewardsResp, _ := rc.beaconClient.FetchBlockRewardsResponse(slotno)
-
-	proposerSlashingsReward, _ := strconv.ParseInt(blockRewardsResp.Data.ProposerSlashings, 10, 64)
-	transactionFees.Add(transactionFees, big.NewInt(proposerSlashingsReward))
-
-	for _, item := range syncCommittee.Data.Validators {
-		curValidatorIndex, _ := strconv.ParseInt(item, 10, 64)
-		if curValidatorIndex == proposerIndex {
-			// add the sync duties reward
-			blockSyncDutyRewTotal, _ := strconv.ParseInt(blockRewardsResp.Data.SyncAggregate, 10, 64)
-			transactionFees.Add(transactionFees, big.NewInt(blockSyncDutyRewTotal/int64(len(syncCommittee.Data.Validators))))
-			break
+// consensusRewards estimates the CL rewards of the proposer for the block in gwei.
+// Parts the beacon node fails to serve are left out.
+func (rc *RewardsClient) consensusRewards(ctx context.Context, slotno, proposerIndex int64) int64 {
+	ctx, span := tracing.Start(ctx, "rewards.cl_rewards", attribute.Int64("slot", slotno))
+	defer span.End()
+	beaconClient := rc.beaconClient.WithContext(ctx)
+	total := int64(0)
+	blockRewardsResp, err := beaconClient.FetchBlockRewardsResponse(slotno)
+	if err == nil {
+		proposerSlashingsReward, _ := strconv.ParseInt(blockRewardsResp.Data.ProposerSlashings, 10, 64)
+		total += proposerSlashingsReward
+
+		syncCommittee, err := beaconClient.FetchSyncDuties(slotno)
+		if err == nil {
+			for _, item := range syncCommittee.Data.Validators {
+				curValidatorIndex, _ := strconv.ParseInt(item, 10, 64)
+				if curValidatorIndex == proposerIndex {
+					// add the sync duties reward
+					blockSyncDutyRewTotal, _ := strconv.ParseInt(blockRewardsResp.Data.SyncAggregate, 10, 64)
+					total += blockSyncDutyRewTotal / int64(len(syncCommittee.Data.Validators))
+					break
+				}
+			}
 		}
 	}
 
 	//nolint:ineffassign // That's expected
-	attestantionRew, _ := rc.beaconClient.FetchAttestationRewardsEstimate(slotno, proposerIndex)
-	transactionFees.Add(transactionFees, big.NewInt(attestantionRew))
+	attestantionRew, _ := beaconClient.FetchAttestationRewardsEstimate(slotno, proposerIndex)
+	return total + attestantionRew
+}
 
-	return &models.BlockReward{
-		Status: isMev,
-		Reward: transactionFees.Int64(),
-	}, nil
+// blockByNumber and transactionReceipt call the execution RPC and record the calls.
+func (rc *RewardsClient) blockByNumber(ctx context.Context, height *big.Int) (*types.Block, error) {
+	ctx, span := tracing.StartUpstream(ctx, metrics.UpstreamExecution, "eth_getBlockByNumber", nil)
+	start := time.Now()
+	block, err := rc.client.BlockByNumber(ctx, height)
+	metrics.ObserveUpstream(metrics.UpstreamExecution, "eth_getBlockByNumber", start, 0, err)
+	tracing.End(span, 0, err)
+	return block, err
+}
+
+func (rc *RewardsClient) transactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	ctx, span := tracing.StartUpstream(ctx, metrics.UpstreamExecution, "eth_getTransactionReceipt", nil)
+	start := time.Now()
+	receipt, err := rc.client.TransactionReceipt(ctx, hash)
+	metrics.ObserveUpstream(metrics.UpstreamExecution, "eth_getTransactionReceipt", start, 0, err)
+	tracing.End(span, 0, err)
+	return receipt, err
+}
+
+func weiToGwei(wei *big.Int) int64 {
+	return new(big.Int).Div(wei, big.NewInt(1e9)).Int64()
 }
 
 func NewRewardsClient(baseURL, ethScanAPIKey string) (*RewardsClient, error) {
@@ -136,7 +269,7 @@ func NewRewardsClient(baseURL, ethScanAPIKey string) (*RewardsClient, error) {
 	}
 	return &RewardsClient{
 		client:       ethClient,
-		ethScan:      &ethScanHelper{apiKey: ethScanAPIKey},
+		ethScan:      newEtherscanHelper(ethScanAPIKey),
 		beaconClient: beaconClient,
 	}, nil
 }
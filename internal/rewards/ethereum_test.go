@@ -49,7 +49,7 @@ func TestIsMevBlock(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			resp, err := rewardsClient.isMevAdress(testCase.address)
+			resp, err := rewardsClient.isMevAdress(context.Background(), testCase.address)
 			require.NoError(t, err)
 			require.Equal(t, testCase.isMev, resp)
 		})
@@ -182,3 +182,17 @@ func TestBlockRewardFull(t *testing.T) {
 		}
 	})
 }
+
+func TestParseMode(t *testing.T) {
+	for requested, expected := range map[string]string{"light": ModeLight, "full": ModeBeast, "beast": ModeBeast} {
+		mode, err := ParseMode(requested)
+		require.NoError(t, err)
+		require.Equal(t, expected, mode)
+	}
+	_, err := ParseMode("turbo")
+	require.ErrorIs(t, err, ErrUnknownMode)
+	_, err = ParseMode("")
+	require.ErrorIs(t, err, ErrUnknownMode)
+	require.Equal(t, ModeFull, APIMode(ModeBeast))
+	require.Equal(t, ModeLight, APIMode(ModeLight))
+}
@@ -1,12 +1,17 @@
 package rewards
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
+
+	"ethereum-validator-api/internal/metrics"
+	"ethereum-validator-api/internal/tracing"
 )
 
 const (
@@ -63,10 +68,12 @@ type EtherscanResponse struct {
 
 type ethScanHelper struct {
 	apiKey string
+	// baseURL is the Etherscan API, replaced in tests by a stand-in
+	baseURL string
 }
 
 func (h *ethScanHelper) etherscanBlockReward(blockHeight int64, withMev bool) (int64, error) {
-	rewardURL := fmt.Sprintf("%s?module=block&action=getblockreward&blockno=%d&apikey=%s", constEtherscanAPILink, blockHeight, h.apiKey)
+	rewardURL := fmt.Sprintf("%s?module=block&action=getblockreward&blockno=%d&apikey=%s", h.baseURL, blockHeight, h.apiKey)
 	blockRewardStr, err := h.fetchBlockReward(rewardURL)
 	if err != nil {
 		return 0, err
@@ -75,7 +82,7 @@ func (h *ethScanHelper) etherscanBlockReward(blockHeight int64, withMev bool) (i
 	if err != nil {
 		return 0, err
 	}
-	transactionsURL := fmt.Sprintf("%s?module=proxy&action=eth_getBlockByNumber&tag=0x%x&boolean=true&apikey=%s", constEtherscanAPILink, blockHeight, h.apiKey)
+	transactionsURL := fmt.Sprintf("%s?module=proxy&action=eth_getBlockByNumber&tag=0x%x&boolean=true&apikey=%s", h.baseURL, blockHeight, h.apiKey)
 	transactionsResp, err := h.fetchBlockTransactions(transactionsURL)
 	if err != nil {
 		return 0, err
@@ -98,8 +105,7 @@ func (h *ethScanHelper) etherscanBlockReward(blockHeight int64, withMev bool) (i
 }
 
 func (h *ethScanHelper) fetchBlockReward(apiURL string) (string, error) {
-	//nolint:gosec // That's expected
-	resp, err := http.Get(apiURL)
+	resp, err := h.get(context.Background(), "getblockreward", apiURL)
 	if err != nil {
 		return "", err
 	}
@@ -120,8 +126,7 @@ func (h *ethScanHelper) fetchBlockReward(apiURL string) (string, error) {
 }
 
 func (h *ethScanHelper) fetchBlockTransactions(apiURL string) (BlockTransactionsResponse, error) {
-	//nolint:gosec // That's expected
-	resp, err := http.Get(apiURL)
+	resp, err := h.get(context.Background(), "eth_getBlockByNumber", apiURL)
 	if err != nil {
 		return BlockTransactionsResponse{}, err
 	}
@@ -140,9 +145,8 @@ func (h *ethScanHelper) fetchBlockTransactions(apiURL string) (BlockTransactions
 
 func (h *ethScanHelper) fetchTransactionByHash(txHash string) (TransactionByHashResponse, error) {
 	//nolint:gosec // That's expected
-	apiURL := fmt.Sprintf("%s?module=proxy&action=eth_getTransactionByHash&txhash=%s&apikey=%s", constEtherscanAPILink, txHash, h.apiKey)
-	//nolint:gosec // That's expected
-	resp, err := http.Get(apiURL)
+	apiURL := fmt.Sprintf("%s?module=proxy&action=eth_getTransactionByHash&txhash=%s&apikey=%s", h.baseURL, txHash, h.apiKey)
+	resp, err := h.get(context.Background(), "eth_getTransactionByHash", apiURL)
 	if err != nil {
 		return TransactionByHashResponse{}, fmt.Errorf("error making HTTP request: %w", err)
 	}
@@ -162,10 +166,9 @@ func (h *ethScanHelper) fetchTransactionByHash(txHash string) (TransactionByHash
 	return txResponse, nil
 }
 
-func (h *ethScanHelper) fetchLastTransactions(address string) ([]Transaction, error) {
-	apiURL := fmt.Sprintf("%s?module=account&action=txlist&address=%s&startblock=0&endblock=99999999&sort=desc&apikey=%s", constEtherscanAPILink, url.QueryEscape(address), h.apiKey)
-	//nolint:gosec // That's expected
-	resp, err := http.Get(apiURL)
+func (h *ethScanHelper) fetchLastTransactions(ctx context.Context, address string) ([]Transaction, error) {
+	apiURL := fmt.Sprintf("%s?module=account&action=txlist&address=%s&startblock=0&endblock=99999999&sort=desc&apikey=%s", h.baseURL, url.QueryEscape(address), h.apiKey)
+	resp, err := h.get(ctx, "txlist", apiURL)
 	if err != nil {
 		return nil, fmt.Errorf("error making HTTP request: %w", err)
 	}
@@ -188,6 +191,24 @@ func (h *ethScanHelper) fetchLastTransactions(address string) ([]Transaction, er
 	return etherscanResp.Result, nil
 }
 
+// get fetches the API URL and records the call under the API action.
+func (h *ethScanHelper) get(ctx context.Context, action, apiURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	ctx, span := tracing.StartUpstream(ctx, metrics.UpstreamEtherscan, action, req)
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	metrics.ObserveUpstream(metrics.UpstreamEtherscan, action, start, status, err)
+	tracing.End(span, status, err)
+	return resp, err
+}
+
 func newEtherscanHelper(apiKey string) *ethScanHelper {
-	return &ethScanHelper{apiKey: apiKey}
+	return &ethScanHelper{apiKey: apiKey, baseURL: constEtherscanAPILink}
 }
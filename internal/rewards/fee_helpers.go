@@ -3,11 +3,14 @@ package rewards
 import (
 	"context"
 	"github.com/ethereum/go-ethereum/core/types"
+	"go.opentelemetry.io/otel/attribute"
 	"log"
 	"math"
 	"math/big"
 	"strings"
 	"time"
+
+	"ethereum-validator-api/internal/tracing"
 )
 
 type MEVBlockResp struct {
@@ -29,13 +32,15 @@ type MEVBlockResp struct {
 	ArrivalTimeAS          string   `json:"arrival_time_as"`
 }
 
-func (rc *RewardsClient) calculateTransactionFees(block *types.Block) (*big.Int, error) {
+func (rc *RewardsClient) calculateTransactionFees(ctx context.Context, block *types.Block) (*big.Int, error) {
+	ctx, span := tracing.Start(ctx, "rewards.receipts", attribute.Int("transactions", len(block.Transactions())))
 	transactionFees := big.NewInt(0)
 	for _, tx := range block.Transactions() {
 		time.Sleep(time.Millisecond * 100)
-		receipt, err := rc.client.TransactionReceipt(context.Background(), tx.Hash())
+		receipt, err := rc.transactionReceipt(ctx, tx.Hash())
 		if err != nil {
 			log.Printf("Failed to fetch transaction receipt for tx %s: %v", tx.Hash().Hex(), err)
+			tracing.End(span, 0, err)
 			return nil, err
 		}
 
@@ -44,6 +49,7 @@ func (rc *RewardsClient) calculateTransactionFees(block *types.Block) (*big.Int,
 		fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
 		transactionFees.Add(transactionFees, fee)
 	}
+	span.End()
 	return transactionFees, nil
 }
 
@@ -60,8 +66,8 @@ func getMEVReward(block *types.Block) *big.Int {
 	return reward
 }
 
-func (rc *RewardsClient) isMevAdress(address string) (bool, error) {
-	transactions, err := rc.ethScan.fetchLastTransactions(address)
+func (rc *RewardsClient) isMevAdress(ctx context.Context, address string) (bool, error) {
+	transactions, err := rc.ethScan.fetchLastTransactions(ctx, address)
 	if err != nil {
 		return false, err
 	}
@@ -70,7 +76,7 @@ func (rc *RewardsClient) isMevAdress(address string) (bool, error) {
 	for i := 0; i < int(math.Min(3, float64(len(transactions)))); i++ {
 		tx := transactions[i]
 		height, _ := new(big.Int).SetString(tx.BlockNumber, 10)
-		correspondingBlock, err := rc.client.BlockByNumber(context.Background(), height)
+		correspondingBlock, err := rc.blockByNumberCached(ctx, height)
 		if err != nil {
 			return false, err
 		}
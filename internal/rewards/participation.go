@@ -0,0 +1,81 @@
+package rewards
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"ethereum-validator-api/internal/beaconadapter"
+)
+
+// committeeKey identifies the committees an aggregate attests for. Before Electra an aggregate covers
+// the committee of its index, from Electra on the committees of its committee bits.
+type committeeKey struct {
+	slot          string
+	index         string
+	committeeBits string
+}
+
+// bitlist is a decoded SSZ bitlist.
+type bitlist struct {
+	bits   []byte
+	length int
+}
+
+// Participation is the share of the attestation duties of the epoch that were fulfilled, from the
+// attestations included in the blocks. The attestations of an epoch are included in its blocks and in the
+// ones of the next epoch, so both should be passed. Only the committees with an attestation in the blocks
+// are counted, the ones nobody attested for are left out.
+func Participation(epoch int64, blocks []*beaconadapter.BlockResponse) (float64, error) {
+	target := strconv.FormatInt(epoch, 10)
+	// the aggregates of the same committees overlap, they are merged
+	committees := make(map[committeeKey]*bitlist)
+	for _, block := range blocks {
+		for _, attestation := range block.Data.Message.Body.Attestations {
+			if attestation.Data.Target.Epoch != target {
+				continue
+			}
+			aggregation, err := parseBitlist(attestation.AggregationBits)
+			if err != nil {
+				return 0, err
+			}
+			key := committeeKey{slot: attestation.Data.Slot, index: attestation.Data.Index, committeeBits: attestation.CommitteeBits}
+			merged, ok := committees[key]
+			if !ok {
+				committees[key] = aggregation
+				continue
+			}
+			if merged.length != aggregation.length {
+				return 0, fmt.Errorf("aggregation bits of slot %s differ in length", attestation.Data.Slot)
+			}
+			for i := range aggregation.bits {
+				merged.bits[i] |= aggregation.bits[i]
+			}
+		}
+	}
+	var attested, total int
+	for _, aggregation := range committees {
+		for _, b := range aggregation.bits {
+			attested += bits.OnesCount8(b)
+		}
+		total += aggregation.length
+	}
+	if total == 0 {
+		return 0, nil
+	}
+	return float64(attested) / float64(total), nil
+}
+
+// parseBitlist decodes a hex SSZ bitlist: the highest bit set marks the length and isn't part of the list.
+func parseBitlist(value string) (*bitlist, error) {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil || len(decoded) == 0 || decoded[len(decoded)-1] == 0 {
+		return nil, fmt.Errorf("invalid bitlist %q", value)
+	}
+	last := len(decoded) - 1
+	lengthBit := bits.Len8(decoded[last]) - 1
+	decoded[last] &^= 1 << lengthBit
+	return &bitlist{bits: decoded, length: 8*last + lengthBit}, nil
+}
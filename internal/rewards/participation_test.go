@@ -0,0 +1,51 @@
+package rewards
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/beaconadapter"
+)
+
+func blockWithAttestations(t *testing.T, attestations string) *beaconadapter.BlockResponse {
+	var block beaconadapter.BlockResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"data":{"message":{"body":{"attestations":[`+attestations+`]}}}}`), &block))
+	return &block
+}
+
+func attestation(bits, slot, index, targetEpoch string) string {
+	return `{"aggregation_bits":"` + bits + `","data":{"slot":"` + slot + `","index":"` + index +
+		`","target":{"epoch":"` + targetEpoch + `"}}}`
+}
+
+func TestParticipation(t *testing.T) {
+	blocks := []*beaconadapter.BlockResponse{
+		// 2 of the 3 members of committee 0, 1 of the 4 of committee 1
+		blockWithAttestations(t, attestation("0x0d", "320", "0", "10")+","+attestation("0x11", "320", "1", "10")),
+		// the aggregate included later adds the third member of committee 0, the other epoch is left out
+		blockWithAttestations(t, attestation("0x0b", "320", "0", "10")+","+attestation("0x01ff", "352", "0", "11")),
+	}
+	participation, err := Participation(10, blocks)
+	require.NoError(t, err)
+	require.InDelta(t, 4.0/7.0, participation, 1e-9)
+
+	participation, err = Participation(12, blocks)
+	require.NoError(t, err)
+	require.Zero(t, participation)
+
+	_, err = Participation(10, []*beaconadapter.BlockResponse{blockWithAttestations(t, attestation("0x00", "320", "0", "10"))})
+	require.Error(t, err)
+}
+
+func TestParseBitlist(t *testing.T) {
+	list, err := parseBitlist("0x0d")
+	require.NoError(t, err)
+	require.Equal(t, 3, list.length)
+	require.Equal(t, []byte{0x05}, list.bits)
+	list, err = parseBitlist("0xff01")
+	require.NoError(t, err)
+	require.Equal(t, 8, list.length)
+	require.Equal(t, []byte{0xff, 0x00}, list.bits)
+}
@@ -0,0 +1,97 @@
+package rewards
+
+import (
+	"fmt"
+	"strconv"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/models"
+)
+
+// constSyncCommitteeSubnetCount is SYNC_COMMITTEE_SUBNET_COUNT from the Altair spec.
+const constSyncCommitteeSubnetCount = 4
+
+// GetSyncCommittee fetches the sync committee of the slot along with the full
+// validator records of its members.
+func (rc *RewardsClient) GetSyncCommittee(slotno int64) (*models.SyncDutiesDetail, error) {
+	dutiesResp, err := rc.beaconClient.FetchSyncDuties(slotno)
+	if err != nil {
+		return nil, err
+	}
+	indices := make([]int64, 0, len(dutiesResp.Data.Validators))
+	for _, item := range dutiesResp.Data.Validators {
+		index, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse validator index: %w", err)
+		}
+		indices = append(indices, index)
+	}
+	validatorResp, err := rc.beaconClient.PublicKeysByValidatorIDs(indices, slotno)
+	if err != nil {
+		return nil, err
+	}
+	members, err := SyncCommitteeMembers(indices, len(dutiesResp.Data.ValidatorAggregates), validatorResp.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SyncDutiesDetail{
+		Validators:          members,
+		ExecutionOptimistic: dutiesResp.ExecutionOptimistic || validatorResp.ExecutionOptimistic,
+		Finalized:           dutiesResp.Finalized,
+	}, nil
+}
+
+// SyncCommitteeMembers lays the fetched validator records out in committee order.
+// The beacon node returns each validator once, sorted by index, while the committee
+// itself may be in any order and may contain the same validator more than once.
+func SyncCommitteeMembers(indices []int64, subcommittees int, validators []beaconadapter.ValidatorData) ([]models.SyncCommitteeMember, error) {
+	if subcommittees == 0 {
+		subcommittees = constSyncCommitteeSubnetCount
+	}
+	subcommitteeSize := len(indices) / subcommittees
+	if subcommitteeSize == 0 {
+		subcommitteeSize = 1
+	}
+	byIndex := make(map[int64]models.SyncCommitteeMember, len(validators))
+	for i := range validators {
+		member, err := ParseValidator(&validators[i])
+		if err != nil {
+			return nil, err
+		}
+		byIndex[member.Index] = member
+	}
+	members := make([]models.SyncCommitteeMember, 0, len(indices))
+	for position, index := range indices {
+		member, ok := byIndex[index]
+		if !ok {
+			return nil, fmt.Errorf("validator %d is missing from the response", index)
+		}
+		member.SubcommitteeIndex = position / subcommitteeSize
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// ParseValidator converts the validator fields shared by all our models.
+func ParseValidator(validator *beaconadapter.ValidatorData) (models.SyncCommitteeMember, error) {
+	index, err := strconv.ParseInt(validator.Index, 10, 64)
+	if err != nil {
+		return models.SyncCommitteeMember{}, fmt.Errorf("failed to parse validator index: %w", err)
+	}
+	balance, err := strconv.ParseInt(validator.Balance, 10, 64)
+	if err != nil {
+		return models.SyncCommitteeMember{}, fmt.Errorf("failed to parse balance of validator %d: %w", index, err)
+	}
+	effectiveBalance, err := strconv.ParseInt(validator.Validator.EffectiveBalance, 10, 64)
+	if err != nil {
+		return models.SyncCommitteeMember{}, fmt.Errorf("failed to parse effective balance of validator %d: %w", index, err)
+	}
+	return models.SyncCommitteeMember{
+		Index:            index,
+		Pubkey:           validator.Validator.Pubkey,
+		Status:           validator.Status,
+		Balance:          balance,
+		EffectiveBalance: effectiveBalance,
+		Slashed:          validator.Validator.Slashed,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package rewards
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/beaconadapter"
+)
+
+func TestSyncCommitteeMembersOrder(t *testing.T) {
+	newValidator := func(index, pubkey string) beaconadapter.ValidatorData {
+		var v beaconadapter.ValidatorData
+		v.Index = index
+		v.Balance = "32000000000"
+		v.Status = "active_ongoing"
+		v.Validator.Pubkey = pubkey
+		v.Validator.EffectiveBalance = "32000000000"
+		return v
+	}
+	// the node answers sorted by index and without duplicates
+	validators := []beaconadapter.ValidatorData{
+		newValidator("1", "0x01"),
+		newValidator("5", "0x05"),
+		newValidator("9", "0x09"),
+	}
+	members, err := SyncCommitteeMembers([]int64{9, 1, 5, 9}, 2, validators)
+	require.NoError(t, err)
+	require.Len(t, members, 4)
+	pubkeys := make([]string, 0, len(members))
+	for _, member := range members {
+		pubkeys = append(pubkeys, member.Pubkey)
+	}
+	assert.Equal(t, []string{"0x09", "0x01", "0x05", "0x09"}, pubkeys)
+	assert.Equal(t, 0, members[1].SubcommitteeIndex)
+	assert.Equal(t, 1, members[2].SubcommitteeIndex)
+	assert.Equal(t, int64(32000000000), members[0].EffectiveBalance)
+
+	_, err = SyncCommitteeMembers([]int64{2}, 1, validators)
+	require.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package rewards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// constWithdrawalsPage is the number of withdrawals Etherscan returns per request
+const constWithdrawalsPage = 1000
+
+// Withdrawal is a withdrawal of the beacon chain to an execution address.
+type Withdrawal struct {
+	Index          int64
+	ValidatorIndex int64
+	Address        string
+	// Amount is in gwei
+	Amount      int64
+	BlockNumber int64
+	Time        time.Time
+}
+
+type withdrawalsResponse struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+type etherscanWithdrawal struct {
+	WithdrawalIndex string `json:"withdrawalIndex"`
+	ValidatorIndex  string `json:"validatorIndex"`
+	Address         string `json:"address"`
+	Amount          string `json:"amount"`
+	BlockNumber     string `json:"blockNumber"`
+	Timestamp       string `json:"timestamp"`
+}
+
+// Withdrawals returns the withdrawals to the address in the execution blocks [fromBlock, toBlock],
+// in withdrawal order, as Etherscan indexes them.
+func (rc *RewardsClient) Withdrawals(ctx context.Context, address string, fromBlock, toBlock int64) ([]Withdrawal, error) {
+	return rc.ethScan.fetchWithdrawals(ctx, address, fromBlock, toBlock)
+}
+
+// fetchWithdrawals pages through the withdrawals by moving the start block to the last block of the
+// previous page, the withdrawals of that block are returned again and left out by their index.
+func (h *ethScanHelper) fetchWithdrawals(ctx context.Context, address string, fromBlock, toBlock int64) ([]Withdrawal, error) {
+	var withdrawals []Withdrawal
+	seen := make(map[int64]bool)
+	for {
+		page, err := h.fetchWithdrawalsPage(ctx, address, fromBlock, toBlock)
+		if err != nil {
+			return nil, err
+		}
+		for _, withdrawal := range page {
+			if seen[withdrawal.Index] {
+				continue
+			}
+			seen[withdrawal.Index] = true
+			withdrawals = append(withdrawals, withdrawal)
+		}
+		if len(page) < constWithdrawalsPage {
+			return withdrawals, nil
+		}
+		last := page[len(page)-1].BlockNumber
+		if last == fromBlock {
+			return nil, fmt.Errorf("more than %d withdrawals to %s in block %d", constWithdrawalsPage, address, last)
+		}
+		fromBlock = last
+	}
+}
+
+func (h *ethScanHelper) fetchWithdrawalsPage(ctx context.Context, address string, fromBlock, toBlock int64) ([]Withdrawal, error) {
+	apiURL := fmt.Sprintf("%s?module=account&action=txsBeaconWithdrawal&address=%s&startblock=%d&endblock=%d&page=1&offset=%d&sort=asc&apikey=%s",
+		h.baseURL, url.QueryEscape(address), fromBlock, toBlock, constWithdrawalsPage, h.apiKey)
+	resp, err := h.get(ctx, "txsBeaconWithdrawal", apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("error making HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status: %s", resp.Status)
+	}
+	var withdrawalsResp withdrawalsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&withdrawalsResp); err != nil {
+		return nil, fmt.Errorf("error parsing JSON response: %w", err)
+	}
+	if withdrawalsResp.Status != "1" {
+		// an address without withdrawals is reported as an error
+		if withdrawalsResp.Message == "No transactions found" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("API returned error: %s: %s", withdrawalsResp.Message, withdrawalsResp.Result)
+	}
+	var result []etherscanWithdrawal
+	if err := json.Unmarshal(withdrawalsResp.Result, &result); err != nil {
+		return nil, fmt.Errorf("error parsing JSON response: %w", err)
+	}
+	withdrawals := make([]Withdrawal, 0, len(result))
+	for _, w := range result {
+		withdrawal, err := w.parse()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse withdrawal %s: %w", w.WithdrawalIndex, err)
+		}
+		withdrawals = append(withdrawals, withdrawal)
+	}
+	return withdrawals, nil
+}
+
+func (w *etherscanWithdrawal) parse() (Withdrawal, error) {
+	var (
+		withdrawal Withdrawal
+		timestamp  int64
+		err        error
+	)
+	for _, field := range []struct {
+		value string
+		into  *int64
+	}{
+		{w.WithdrawalIndex, &withdrawal.Index},
+		{w.ValidatorIndex, &withdrawal.ValidatorIndex},
+		{w.Amount, &withdrawal.Amount},
+		{w.BlockNumber, &withdrawal.BlockNumber},
+		{w.Timestamp, &timestamp},
+	} {
+		if *field.into, err = strconv.ParseInt(field.value, 10, 64); err != nil {
+			return Withdrawal{}, err
+		}
+	}
+	withdrawal.Address = w.Address
+	withdrawal.Time = time.Unix(timestamp, 0).UTC()
+	return withdrawal, nil
+}
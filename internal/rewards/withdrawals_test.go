@@ -0,0 +1,71 @@
+package rewards
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithdrawals(t *testing.T) {
+	// a full page of withdrawals in blocks 100-199, the last ten in block 199, and ten more in block 199
+	var all []etherscanWithdrawal
+	for i := 0; i < constWithdrawalsPage+10; i++ {
+		block := int64(100 + min(i/10, 99))
+		all = append(all, etherscanWithdrawal{
+			WithdrawalIndex: strconv.Itoa(5000 + i),
+			ValidatorIndex:  "42",
+			Address:         "0xabc",
+			Amount:          "17000000",
+			BlockNumber:     strconv.FormatInt(block, 10),
+			Timestamp:       strconv.FormatInt(1735689600+block*12, 10),
+		})
+	}
+	var starts []string
+	etherscan := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "txsBeaconWithdrawal", query.Get("action"))
+		starts = append(starts, query.Get("startblock"))
+		if query.Get("address") != "0xabc" {
+			//nolint:errcheck // That's expected
+			w.Write([]byte(`{"status":"0","message":"No transactions found","result":[]}`))
+			return
+		}
+		start, _ := strconv.ParseInt(query.Get("startblock"), 10, 64)
+		page := make([]etherscanWithdrawal, 0, constWithdrawalsPage)
+		for _, withdrawal := range all {
+			block, _ := strconv.ParseInt(withdrawal.BlockNumber, 10, 64)
+			if block >= start && len(page) < constWithdrawalsPage {
+				page = append(page, withdrawal)
+			}
+		}
+		//nolint:errcheck // That's expected
+		json.NewEncoder(w).Encode(map[string]any{"status": "1", "message": "OK", "result": page})
+	}))
+	defer etherscan.Close()
+	h := &ethScanHelper{baseURL: etherscan.URL}
+
+	withdrawals, err := h.fetchWithdrawals(context.Background(), "0xabc", 100, 300)
+	require.NoError(t, err)
+	require.Len(t, withdrawals, constWithdrawalsPage+10)
+	assert.Equal(t, []string{"100", "199"}, starts)
+	assert.Equal(t, Withdrawal{
+		Index:          5000,
+		ValidatorIndex: 42,
+		Address:        "0xabc",
+		Amount:         17000000,
+		BlockNumber:    100,
+		Time:           time.Unix(1735689600+1200, 0).UTC(),
+	}, withdrawals[0])
+	assert.Equal(t, int64(5000+constWithdrawalsPage+9), withdrawals[len(withdrawals)-1].Index)
+
+	withdrawals, err = h.fetchWithdrawals(context.Background(), "0xdef", 100, 300)
+	require.NoError(t, err)
+	assert.Empty(t, withdrawals)
+}
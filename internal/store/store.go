@@ -0,0 +1,316 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"ethereum-validator-api/models"
+)
+
+// ErrLocked is returned by Open when another process, usually the server, has the store open.
+var ErrLocked = errors.New("store is in use by another process")
+
+var (
+	bucketBlockRewards   = []byte("block_rewards")
+	bucketSyncCommittees = []byte("sync_committees")
+	bucketCheckpoints    = []byte("checkpoints")
+	// bucketUnfinalized indexes the slots with block rewards that are not finalized yet
+	bucketUnfinalized = []byte("unfinalized")
+	bucketWatchlist   = []byte("watchlist")
+	bucketWithdrawals = []byte("withdrawals")
+
+	keyWatchlistValidators = []byte("validators")
+)
+
+// Store keeps computed results on disk, keyed by slot.
+// It's a plain bbolt file, so only one process can have it open at a time.
+type Store struct {
+	db *bbolt.DB
+}
+
+func Open(file string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory: %w", err)
+	}
+	db, err := bbolt.Open(file, 0o600, &bbolt.Options{Timeout: time.Second})
+	if errors.Is(err, bbolt.ErrTimeout) {
+		return nil, ErrLocked
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store: %w", err)
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, bucket := range [][]byte{bucketBlockRewards, bucketSyncCommittees, bucketCheckpoints, bucketUnfinalized, bucketWatchlist, bucketWithdrawals} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to init store: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// GetBlockReward returns the reward computed for the slot in the given mode, nil if there is none.
+func (s *Store) GetBlockReward(slot int64, mode string) (*models.RewardBreakdown, error) {
+	var reward *models.RewardBreakdown
+	err := s.get(bucketBlockRewards, blockRewardKey(slot, mode), &reward)
+	return reward, err
+}
+
+// PutBlockReward stores the reward. Not finalized rewards are kept track of
+// until MarkFinalized covers their slot.
+func (s *Store) PutBlockReward(reward *models.RewardBreakdown) error {
+	raw, err := json.Marshal(reward)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s record: %w", bucketBlockRewards, err)
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.Bucket(bucketBlockRewards).Put(blockRewardKey(reward.Slot, reward.Mode), raw); err != nil {
+			return err
+		}
+		if reward.Finalized {
+			return nil
+		}
+		return tx.Bucket(bucketUnfinalized).Put(slotKey(reward.Slot), []byte{})
+	})
+}
+
+// MarkFinalized flags the stored rewards of all slots up to the given one as finalized
+// and returns the number of slots it flagged.
+func (s *Store) MarkFinalized(slot int64) (int, error) {
+	marked := 0
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		unfinalized := tx.Bucket(bucketUnfinalized)
+		blockRewards := tx.Bucket(bucketBlockRewards)
+		cursor := unfinalized.Cursor()
+		for key, _ := cursor.First(); key != nil && bytes.Compare(key, slotKey(slot)) <= 0; key, _ = cursor.First() {
+			// bbolt cursors don't survive writes, so the updates are collected first
+			updates := make(map[string][]byte)
+			rewardsCursor := blockRewards.Cursor()
+			for rewardKey, raw := rewardsCursor.Seek(key); rewardKey != nil && bytes.HasPrefix(rewardKey, key); rewardKey, raw = rewardsCursor.Next() {
+				var reward models.RewardBreakdown
+				if err := json.Unmarshal(raw, &reward); err != nil {
+					return fmt.Errorf("failed to decode %s record: %w", bucketBlockRewards, err)
+				}
+				reward.Finalized = true
+				updated, err := json.Marshal(&reward)
+				if err != nil {
+					return fmt.Errorf("failed to encode %s record: %w", bucketBlockRewards, err)
+				}
+				updates[string(rewardKey)] = updated
+			}
+			for rewardKey, updated := range updates {
+				if err := blockRewards.Put([]byte(rewardKey), updated); err != nil {
+					return err
+				}
+			}
+			if err := cursor.Delete(); err != nil {
+				return err
+			}
+			marked++
+		}
+		return nil
+	})
+	return marked, err
+}
+
+// LastBlockReward returns the stored reward in the given mode with the highest slot in [from, to],
+// nil if there is none.
+func (s *Store) LastBlockReward(mode string, from, to int64) (*models.RewardBreakdown, error) {
+	var reward *models.RewardBreakdown
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		cursor := tx.Bucket(bucketBlockRewards).Cursor()
+		key, raw := cursor.Seek(slotKey(to + 1))
+		if key == nil {
+			key, raw = cursor.Last()
+		} else {
+			key, raw = cursor.Prev()
+		}
+		for ; key != nil && bytes.Compare(key, slotKey(max(from, 0))) >= 0; key, raw = cursor.Prev() {
+			if string(key[len(slotKey(0)):]) != mode {
+				continue
+			}
+			if err := json.Unmarshal(raw, &reward); err != nil {
+				return fmt.Errorf("failed to decode %s record: %w", bucketBlockRewards, err)
+			}
+			return nil
+		}
+		return nil
+	})
+	return reward, err
+}
+
+// BlockRewards returns the stored rewards in the given mode for the slots in [from, to], in slot order.
+func (s *Store) BlockRewards(mode string, from, to int64) ([]*models.RewardBreakdown, error) {
+	var rewards []*models.RewardBreakdown
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		cursor := tx.Bucket(bucketBlockRewards).Cursor()
+		for key, raw := cursor.Seek(slotKey(max(from, 0))); key != nil && bytes.Compare(key[:len(slotKey(0))], slotKey(to)) <= 0; key, raw = cursor.Next() {
+			if string(key[len(slotKey(0)):]) != mode {
+				continue
+			}
+			var reward models.RewardBreakdown
+			if err := json.Unmarshal(raw, &reward); err != nil {
+				return fmt.Errorf("failed to decode %s record: %w", bucketBlockRewards, err)
+			}
+			rewards = append(rewards, &reward)
+		}
+		return nil
+	})
+	return rewards, err
+}
+
+// InvalidateBlockRewards drops the not finalized rewards of the slots in [from, to] in all modes
+// and returns the slots it dropped. Finalized rewards can't be reorged, so they are kept.
+func (s *Store) InvalidateBlockRewards(from, to int64) ([]int64, error) {
+	var dropped []int64
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		unfinalized := tx.Bucket(bucketUnfinalized)
+		blockRewards := tx.Bucket(bucketBlockRewards)
+		// bbolt cursors don't survive writes, so the keys are collected first
+		var slotKeys, rewardKeys [][]byte
+		cursor := unfinalized.Cursor()
+		for key, _ := cursor.Seek(slotKey(max(from, 0))); key != nil && bytes.Compare(key, slotKey(to)) <= 0; key, _ = cursor.Next() {
+			slotKeys = append(slotKeys, bytes.Clone(key))
+		}
+		for _, key := range slotKeys {
+			rewardsCursor := blockRewards.Cursor()
+			for rewardKey, raw := rewardsCursor.Seek(key); rewardKey != nil && bytes.HasPrefix(rewardKey, key); rewardKey, raw = rewardsCursor.Next() {
+				var reward models.RewardBreakdown
+				if err := json.Unmarshal(raw, &reward); err != nil {
+					return fmt.Errorf("failed to decode %s record: %w", bucketBlockRewards, err)
+				}
+				if !reward.Finalized {
+					rewardKeys = append(rewardKeys, bytes.Clone(rewardKey))
+				}
+			}
+			dropped = append(dropped, int64(binary.BigEndian.Uint64(key)))
+		}
+		for _, rewardKey := range rewardKeys {
+			if err := blockRewards.Delete(rewardKey); err != nil {
+				return err
+			}
+		}
+		for _, key := range slotKeys {
+			if err := unfinalized.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return dropped, err
+}
+
+// GetSyncCommittee returns the sync committee stored for the slot, nil if there is none.
+func (s *Store) GetSyncCommittee(slot int64) (*models.SyncDutiesDetail, error) {
+	var duties *models.SyncDutiesDetail
+	err := s.get(bucketSyncCommittees, slotKey(slot), &duties)
+	return duties, err
+}
+
+func (s *Store) PutSyncCommittee(slot int64, duties *models.SyncDutiesDetail) error {
+	return s.put(bucketSyncCommittees, slotKey(slot), duties)
+}
+
+// GetWithdrawals returns the withdrawals stored for the slot, nil if there are none.
+func (s *Store) GetWithdrawals(slot int64) (*models.SlotWithdrawals, error) {
+	var withdrawals *models.SlotWithdrawals
+	err := s.get(bucketWithdrawals, slotKey(slot), &withdrawals)
+	return withdrawals, err
+}
+
+// PutWithdrawals stores the withdrawals of a block, only finalized blocks are expected.
+func (s *Store) PutWithdrawals(withdrawals *models.SlotWithdrawals) error {
+	return s.put(bucketWithdrawals, slotKey(withdrawals.Slot), withdrawals)
+}
+
+// WithdrawalsRange returns the stored withdrawals of the slots in [from, to], in slot order.
+func (s *Store) WithdrawalsRange(from, to int64) ([]*models.SlotWithdrawals, error) {
+	var result []*models.SlotWithdrawals
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		cursor := tx.Bucket(bucketWithdrawals).Cursor()
+		for key, raw := cursor.Seek(slotKey(max(from, 0))); key != nil && bytes.Compare(key, slotKey(to)) <= 0; key, raw = cursor.Next() {
+			var withdrawals models.SlotWithdrawals
+			if err := json.Unmarshal(raw, &withdrawals); err != nil {
+				return fmt.Errorf("failed to decode %s record: %w", bucketWithdrawals, err)
+			}
+			result = append(result, &withdrawals)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// GetCheckpoint returns the last slot a named job got through, ok is false if it never ran.
+func (s *Store) GetCheckpoint(name string) (slot int64, ok bool, err error) {
+	var checkpoint *int64
+	if err := s.get(bucketCheckpoints, []byte(name), &checkpoint); err != nil || checkpoint == nil {
+		return 0, false, err
+	}
+	return *checkpoint, true, nil
+}
+
+func (s *Store) PutCheckpoint(name string, slot int64) error {
+	return s.put(bucketCheckpoints, []byte(name), slot)
+}
+
+// GetWatchlist returns the indices of the watched validators.
+func (s *Store) GetWatchlist() ([]int64, error) {
+	var indices []int64
+	err := s.get(bucketWatchlist, keyWatchlistValidators, &indices)
+	return indices, err
+}
+
+func (s *Store) PutWatchlist(indices []int64) error {
+	return s.put(bucketWatchlist, keyWatchlistValidators, indices)
+}
+
+func (s *Store) get(bucket, key []byte, value any) error {
+	return s.db.View(func(tx *bbolt.Tx) error {
+		raw := tx.Bucket(bucket).Get(key)
+		if raw == nil {
+			return nil
+		}
+		if err := json.Unmarshal(raw, value); err != nil {
+			return fmt.Errorf("failed to decode %s record: %w", bucket, err)
+		}
+		return nil
+	})
+}
+
+func (s *Store) put(bucket, key []byte, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s record: %w", bucket, err)
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucket).Put(key, raw)
+	})
+}
+
+// slotKey is big endian, so the keys sort in slot order
+func slotKey(slot int64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(slot))
+	return key
+}
+
+func blockRewardKey(slot int64, mode string) []byte {
+	return append(slotKey(slot), mode...)
+}
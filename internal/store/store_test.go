@@ -0,0 +1,142 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/models"
+)
+
+func TestOpenLocked(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "store.db")
+	s, err := Open(file)
+	require.NoError(t, err)
+	defer s.Close()
+	_, err = Open(file)
+	require.ErrorIs(t, err, ErrLocked)
+}
+
+func TestStore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "store.db")
+	s, err := Open(file)
+	require.NoError(t, err)
+
+	t.Run("block rewards are keyed by slot and mode", func(t *testing.T) {
+		light := &models.RewardBreakdown{Slot: 100, Mode: "light", Reward: 10, Finalized: true}
+		beast := &models.RewardBreakdown{Slot: 100, Mode: "beast", Reward: 12, Finalized: true}
+		require.NoError(t, s.PutBlockReward(light))
+		require.NoError(t, s.PutBlockReward(beast))
+
+		stored, err := s.GetBlockReward(100, "light")
+		require.NoError(t, err)
+		require.Equal(t, light, stored)
+		stored, err = s.GetBlockReward(100, "beast")
+		require.NoError(t, err)
+		require.Equal(t, beast, stored)
+		stored, err = s.GetBlockReward(101, "light")
+		require.NoError(t, err)
+		require.Nil(t, stored)
+	})
+
+	t.Run("sync committees", func(t *testing.T) {
+		duties := &models.SyncDutiesDetail{Validators: []models.SyncCommitteeMember{{Index: 1, Pubkey: "0x01"}}}
+		require.NoError(t, s.PutSyncCommittee(100, duties))
+		stored, err := s.GetSyncCommittee(100)
+		require.NoError(t, err)
+		require.Equal(t, duties, stored)
+	})
+
+	t.Run("checkpoints", func(t *testing.T) {
+		_, ok, err := s.GetCheckpoint("backfill")
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.NoError(t, s.PutCheckpoint("backfill", 42))
+		slot, ok, err := s.GetCheckpoint("backfill")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, int64(42), slot)
+	})
+
+	t.Run("reorged rewards are dropped", func(t *testing.T) {
+		for slot := int64(200); slot <= 203; slot++ {
+			require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: slot, Mode: "light", BlockRoot: "0xaa"}))
+		}
+		require.NoError(t, s.PutBlockReward(&models.RewardBreakdown{Slot: 202, Mode: "beast"}))
+		last, err := s.LastBlockReward("beast", 150, 210)
+		require.NoError(t, err)
+		require.Equal(t, int64(202), last.Slot)
+		last, err = s.LastBlockReward("light", 150, 202)
+		require.NoError(t, err)
+		require.Equal(t, int64(202), last.Slot)
+
+		rewards, err := s.BlockRewards("light", 201, 202)
+		require.NoError(t, err)
+		require.Len(t, rewards, 2)
+		require.Equal(t, int64(201), rewards[0].Slot)
+		require.Equal(t, int64(202), rewards[1].Slot)
+
+		dropped, err := s.InvalidateBlockRewards(202, 300)
+		require.NoError(t, err)
+		require.Equal(t, []int64{202, 203}, dropped)
+		last, err = s.LastBlockReward("light", 150, 210)
+		require.NoError(t, err)
+		require.Equal(t, int64(201), last.Slot)
+		last, err = s.LastBlockReward("beast", 150, 210)
+		require.NoError(t, err)
+		require.Nil(t, last)
+
+		marked, err := s.MarkFinalized(200)
+		require.NoError(t, err)
+		require.Equal(t, 1, marked)
+		// finalized rewards stay
+		dropped, err = s.InvalidateBlockRewards(0, 300)
+		require.NoError(t, err)
+		require.Equal(t, []int64{201}, dropped)
+		stored, err := s.GetBlockReward(200, "light")
+		require.NoError(t, err)
+		require.True(t, stored.Finalized)
+		stored, err = s.GetBlockReward(100, "light")
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+	})
+
+	t.Run("watchlist", func(t *testing.T) {
+		indices, err := s.GetWatchlist()
+		require.NoError(t, err)
+		require.Empty(t, indices)
+		require.NoError(t, s.PutWatchlist([]int64{3, 7}))
+		indices, err = s.GetWatchlist()
+		require.NoError(t, err)
+		require.Equal(t, []int64{3, 7}, indices)
+	})
+
+	t.Run("withdrawals", func(t *testing.T) {
+		for _, slot := range []int64{200, 202, 205} {
+			require.NoError(t, s.PutWithdrawals(&models.SlotWithdrawals{Slot: slot, Finalized: true,
+				Withdrawals: []models.Withdrawal{{Index: slot, Slot: slot, ValidatorIndex: 9, Amount: 17}}}))
+		}
+		stored, err := s.GetWithdrawals(202)
+		require.NoError(t, err)
+		require.Equal(t, int64(202), stored.Withdrawals[0].Index)
+		stored, err = s.GetWithdrawals(201)
+		require.NoError(t, err)
+		require.Nil(t, stored)
+		inRange, err := s.WithdrawalsRange(201, 205)
+		require.NoError(t, err)
+		require.Len(t, inRange, 2)
+		require.Equal(t, int64(202), inRange[0].Slot)
+		require.Equal(t, int64(205), inRange[1].Slot)
+	})
+
+	t.Run("survives reopening", func(t *testing.T) {
+		require.NoError(t, s.Close())
+		s, err = Open(file)
+		require.NoError(t, err)
+		stored, err := s.GetBlockReward(100, "beast")
+		require.NoError(t, err)
+		require.Equal(t, int64(12), stored.Reward)
+	})
+	require.NoError(t, s.Close())
+}
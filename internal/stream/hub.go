@@ -0,0 +1,83 @@
+package stream
+
+import (
+	"sync"
+
+	"ethereum-validator-api/models"
+)
+
+// Hub fans the rewards computed for new head blocks out to the stream subscribers.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[chan *models.RewardBreakdown]struct{}
+	closed      bool
+}
+
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[chan *models.RewardBreakdown]struct{})}
+}
+
+// Subscribe returns a channel of the published rewards and a function to unsubscribe.
+// A subscriber that falls more than buffer rewards behind is dropped and its channel
+// closed, it's expected to reconnect and resume from the store.
+// Once the hub is closed, the channel comes closed.
+func (h *Hub) Subscribe(buffer int) (<-chan *models.RewardBreakdown, func()) {
+	ch := make(chan *models.RewardBreakdown, buffer)
+	h.mu.Lock()
+	if h.closed {
+		close(ch)
+	} else {
+		h.subscribers[ch] = struct{}{}
+	}
+	h.mu.Unlock()
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.drop(ch)
+	}
+}
+
+// Publish hands the reward to every subscriber without ever blocking the caller.
+func (h *Hub) Publish(reward *models.RewardBreakdown) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- reward:
+		default:
+			h.drop(ch)
+		}
+	}
+}
+
+// Close drops all the subscribers, ending their streams, e.g. when the server shuts down.
+// Publishing to a closed hub does nothing.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for ch := range h.subscribers {
+		h.drop(ch)
+	}
+}
+
+// Closed tells whether the hub was closed.
+func (h *Hub) Closed() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.closed
+}
+
+// Len returns the number of subscribers.
+func (h *Hub) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers)
+}
+
+func (h *Hub) drop(ch chan *models.RewardBreakdown) {
+	if _, ok := h.subscribers[ch]; ok {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,51 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/models"
+)
+
+func TestHub(t *testing.T) {
+	hub := NewHub()
+	fast, unsubscribeFast := hub.Subscribe(2)
+	slow, unsubscribeSlow := hub.Subscribe(1)
+	defer unsubscribeSlow()
+
+	hub.Publish(&models.RewardBreakdown{Slot: 1})
+	require.Equal(t, int64(1), (<-fast).Slot)
+	hub.Publish(&models.RewardBreakdown{Slot: 2})
+	require.Equal(t, int64(2), (<-fast).Slot)
+
+	// the slow subscriber is dropped instead of holding the publisher up
+	require.Equal(t, 1, hub.Len())
+	require.Equal(t, int64(1), (<-slow).Slot)
+	_, ok := <-slow
+	require.False(t, ok)
+
+	unsubscribeFast()
+	unsubscribeFast()
+	require.Equal(t, 0, hub.Len())
+	_, ok = <-fast
+	require.False(t, ok)
+}
+
+func TestHubClose(t *testing.T) {
+	hub := NewHub()
+	ch, unsubscribe := hub.Subscribe(1)
+	defer unsubscribe()
+
+	hub.Close()
+	require.True(t, hub.Closed())
+	_, ok := <-ch
+	require.False(t, ok)
+	hub.Publish(&models.RewardBreakdown{Slot: 1})
+
+	late, unsubscribeLate := hub.Subscribe(1)
+	defer unsubscribeLate()
+	_, ok = <-late
+	require.False(t, ok)
+	require.Equal(t, 0, hub.Len())
+}
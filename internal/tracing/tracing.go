@@ -0,0 +1,128 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	constInstrumentationName = "ethereum-validator-api"
+
+	// DefaultServiceName is the service name of the exported spans when none is configured
+	DefaultServiceName = "mewatcher"
+)
+
+// Config sets up the export of the spans to an OTLP/HTTP collector.
+type Config struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Endpoint is the collector URL, the spans are posted to its /v1/traces path
+	Endpoint    string `mapstructure:"endpoint"`
+	ServiceName string `mapstructure:"service_name"`
+	// SampleRatio is the share of the new traces that are recorded, traces started upstream keep their decision
+	SampleRatio float64           `mapstructure:"sample_ratio"`
+	Headers     map[string]string `mapstructure:"headers"`
+}
+
+// Setup installs the global tracer provider exporting to the collector and the W3C trace context propagator.
+// The returned function flushes the pending spans and stops the exporter.
+// When tracing is disabled nothing is recorded, but the trace context of the incoming requests
+// still reaches the logs and the upstreams.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = DefaultServiceName
+	}
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(cfg.Endpoint)}
+	if len(cfg.Headers) > 0 {
+		options = append(options, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the trace exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the trace resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start starts an internal span named after a step of the computation.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(constInstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartUpstream starts the client span of a call to an upstream, named like the upstream metrics.
+// For HTTP calls, req gets the trace context headers so the upstream can join the trace; it is nil for JSON-RPC calls.
+func StartUpstream(ctx context.Context, upstream, method string, req *http.Request) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attribute.String("upstream", upstream),
+		attribute.String("upstream.method", method),
+	}
+	if req != nil {
+		attrs = append(attrs, semconv.HTTPRequestMethodKey.String(req.Method), semconv.ServerAddress(req.URL.Hostname()))
+	}
+	ctx, span := otel.Tracer(constInstrumentationName).Start(ctx, upstream+" "+method,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	if req != nil {
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	}
+	return ctx, span
+}
+
+// End records the error, if any, and ends the span. status is the HTTP status code of the answer, 0 when there is none.
+func End(span trace.Span, status int, err error) {
+	if status != 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+	}
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case status >= http.StatusInternalServerError:
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+	span.End()
+}
+
+// LogHook adds the ids of the current span to the log entries made with a context, e.g. logrus.WithContext(ctx).
+type LogHook struct{}
+
+func (LogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (LogHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(entry.Context)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	entry.Data["trace_id"] = spanContext.TraceID().String()
+	entry.Data["span_id"] = spanContext.SpanID().String()
+	return nil
+}
@@ -0,0 +1,134 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collector stands in for an OTLP/HTTP collector and keeps the spans it is sent.
+type collector struct {
+	mu      sync.Mutex
+	spans   []*tracepb.Span
+	service string
+}
+
+func (col *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/traces" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var request collectortrace.ExportTraceServiceRequest
+	if err := proto.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	col.mu.Lock()
+	defer col.mu.Unlock()
+	for _, resourceSpans := range request.ResourceSpans {
+		for _, attr := range resourceSpans.Resource.Attributes {
+			if attr.Key == "service.name" {
+				col.service = attr.Value.GetStringValue()
+			}
+		}
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			col.spans = append(col.spans, scopeSpans.Spans...)
+		}
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (col *collector) span(name string) *tracepb.Span {
+	col.mu.Lock()
+	defer col.mu.Unlock()
+	for _, span := range col.spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func TestSetupExportsSpans(t *testing.T) {
+	col := &collector{}
+	collectorServer := httptest.NewServer(col)
+	defer collectorServer.Close()
+	var traceparent string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer upstream.Close()
+
+	shutdown, err := Setup(context.Background(), Config{
+		Enabled:     true,
+		Endpoint:    collectorServer.URL,
+		ServiceName: "mewatcher-test",
+		SampleRatio: 1,
+	})
+	require.NoError(t, err)
+
+	ctx, step := Start(context.Background(), "rewards.cl_rewards")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, upstream.URL, http.NoBody)
+	require.NoError(t, err)
+	_, span := StartUpstream(ctx, "beacon", "FetchBlockRewardsResponse", req)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	End(span, resp.StatusCode, nil)
+	End(step, 0, errors.New("no rewards"))
+	require.NoError(t, shutdown(context.Background()))
+
+	require.Equal(t, "mewatcher-test", col.service)
+	stepSpan := col.span("rewards.cl_rewards")
+	require.NotNil(t, stepSpan)
+	require.Equal(t, tracepb.Status_STATUS_CODE_ERROR, stepSpan.Status.Code)
+	upstreamSpan := col.span("beacon FetchBlockRewardsResponse")
+	require.NotNil(t, upstreamSpan)
+	require.Equal(t, tracepb.Span_SPAN_KIND_CLIENT, upstreamSpan.Kind)
+	require.Equal(t, tracepb.Status_STATUS_CODE_ERROR, upstreamSpan.Status.Code)
+	require.Equal(t, stepSpan.TraceId, upstreamSpan.TraceId)
+	require.Equal(t, stepSpan.SpanId, upstreamSpan.ParentSpanId)
+	// the upstream was sent the context of its client span
+	require.Contains(t, traceparent, hex.EncodeToString(upstreamSpan.TraceId)+"-"+hex.EncodeToString(upstreamSpan.SpanId))
+}
+
+func TestLogHook(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Config{Enabled: true, Endpoint: "http://127.0.0.1:1", SampleRatio: 1})
+	require.NoError(t, err)
+	defer shutdown(context.Background()) //nolint:errcheck // nothing is listening
+
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.AddHook(LogHook{})
+
+	ctx, span := Start(context.Background(), "handler")
+	logger.WithContext(ctx).Info("in a span")
+	span.End()
+	require.Contains(t, out.String(), `"trace_id":"`+span.SpanContext().TraceID().String()+`"`)
+	require.Contains(t, out.String(), `"span_id":"`+span.SpanContext().SpanID().String()+`"`)
+
+	out.Reset()
+	logger.Info("without a context")
+	require.NotContains(t, out.String(), "trace_id")
+}
@@ -0,0 +1,273 @@
+package watch
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/metrics"
+	"ethereum-validator-api/models"
+)
+
+const (
+	RuleMissedProposal       = "missed_proposal"
+	RuleMissedSyncSignature  = "missed_sync_signature"
+	RuleBalanceDecrease      = "balance_decrease"
+	RuleSlashed              = "slashed"
+	RuleSyncCommitteeEntered = "sync_committee_entered"
+
+	SeverityCritical = "critical"
+	SeverityError    = "error"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+
+	// constEpochDelaySlots gives late blocks of an epoch some time before it is checked
+	constEpochDelaySlots = 4
+)
+
+// AllRules are the rules checked when none are configured.
+var AllRules = []string{
+	RuleMissedProposal,
+	RuleMissedSyncSignature,
+	RuleBalanceDecrease,
+	RuleSlashed,
+	RuleSyncCommitteeEntered,
+}
+
+// Watcher checks the rules for the watched validators once per epoch and fires alerts.
+type Watcher struct {
+	beacon    *beaconadapter.BeaconClient
+	watchlist *Watchlist
+	notifier  *Notifier
+	rules     map[string]bool
+	// balanceEpochs is the window of the balance decrease rule
+	balanceEpochs int
+
+	// balances holds the last balanceEpochs+1 balances of every watched validator
+	balances   map[int64][]int64
+	decreasing map[int64]bool
+	slashed    map[int64]bool
+}
+
+func NewWatcher(beacon *beaconadapter.BeaconClient, watchlist *Watchlist, notifier *Notifier, rules []string, balanceEpochs int) (*Watcher, error) {
+	if len(rules) == 0 {
+		rules = AllRules
+	}
+	w := &Watcher{
+		beacon:        beacon,
+		watchlist:     watchlist,
+		notifier:      notifier,
+		rules:         make(map[string]bool),
+		balanceEpochs: max(balanceEpochs, 1),
+		balances:      make(map[int64][]int64),
+		decreasing:    make(map[int64]bool),
+		slashed:       make(map[int64]bool),
+	}
+	for _, rule := range rules {
+		switch rule {
+		case RuleMissedProposal, RuleMissedSyncSignature, RuleBalanceDecrease, RuleSlashed, RuleSyncCommitteeEntered:
+			w.rules[rule] = true
+		default:
+			return nil, fmt.Errorf("unknown watch rule %q", rule)
+		}
+	}
+	return w, nil
+}
+
+// Run checks every epoch shortly after it ends, starting with the last complete one,
+// until the context is canceled.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(beaconadapter.EthereumSlotDuration * time.Second)
+	defer ticker.Stop()
+	lastEpoch := w.completedEpoch() - 1
+	for {
+		for epoch := lastEpoch + 1; epoch <= w.completedEpoch(); epoch++ {
+			alerts, err := w.CheckEpoch(epoch)
+			if err != nil {
+				// the rules that did run have their alerts sent, the epoch is not checked again
+				logrus.WithError(err).Errorf("failed to check the watchlist rules for epoch %v", epoch)
+			}
+			for _, alert := range alerts {
+				logrus.Warnf("watchlist alert %v: %v", alert.Rule, alert.Message)
+				if err := w.notifier.Notify(ctx, alert); err != nil {
+					logrus.WithError(err).Error("failed to send the watchlist alert")
+				}
+			}
+			lastEpoch = epoch
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) completedEpoch() int64 {
+	slot := w.beacon.MapTimestampToSlot(time.Now()) - constEpochDelaySlots
+	return slot/beaconadapter.EthereumSlotsPerEpoch - 1
+}
+
+// CheckEpoch runs the rules for the watched validators over the epoch and returns the alerts they fired.
+// The balance and slashing rules keep state between calls, so epochs are expected in order.
+func (w *Watcher) CheckEpoch(epoch int64) ([]*models.Alert, error) {
+	indices := w.watchlist.Indices()
+	if len(indices) == 0 {
+		return nil, nil
+	}
+	watched := make(map[int64]bool, len(indices))
+	for _, index := range indices {
+		watched[index] = true
+	}
+	firstSlot := epoch * beaconadapter.EthereumSlotsPerEpoch
+	lastSlot := firstSlot + beaconadapter.EthereumSlotsPerEpoch - 1
+	var alerts []*models.Alert
+	alert := func(rule, severity string, index, slot int64, format string, args ...any) {
+		alerts = append(alerts, &models.Alert{
+			Rule:           rule,
+			Severity:       severity,
+			ValidatorIndex: index,
+			Epoch:          epoch,
+			Slot:           slot,
+			Message:        fmt.Sprintf(format, args...),
+			Time:           time.Now().UTC(),
+		})
+	}
+
+	if w.rules[RuleMissedProposal] {
+		missedSlots, err := w.beacon.MissedSlots(firstSlot, lastSlot)
+		if err != nil {
+			return alerts, fmt.Errorf("failed to fetch missed slots: %w", err)
+		}
+		for _, missed := range missedSlots {
+			if watched[missed.ProposerIndex] {
+				alert(RuleMissedProposal, SeverityError, missed.ProposerIndex, missed.Slot,
+					"validator %d missed its proposal in slot %d", missed.ProposerIndex, missed.Slot)
+			}
+		}
+	}
+
+	if w.rules[RuleMissedSyncSignature] || w.rules[RuleSyncCommitteeEntered] {
+		dutiesResp, err := w.beacon.FetchSyncDuties(firstSlot)
+		if err != nil {
+			return alerts, fmt.Errorf("failed to fetch the sync committee: %w", err)
+		}
+		// positions of the watched validators in the committee, a validator may hold several
+		positions := make(map[int64][]int)
+		for position, item := range dutiesResp.Data.Validators {
+			index, err := strconv.ParseInt(item, 10, 64)
+			if err != nil {
+				return alerts, fmt.Errorf("failed to parse validator index: %w", err)
+			}
+			if watched[index] {
+				positions[index] = append(positions[index], position)
+			}
+		}
+		if w.rules[RuleSyncCommitteeEntered] && epoch%beaconadapter.EpochsPerSyncCommitteePeriod == 0 {
+			for _, index := range indices {
+				if len(positions[index]) > 0 {
+					alert(RuleSyncCommitteeEntered, SeverityInfo, index, firstSlot,
+						"validator %d entered the sync committee in epoch %d", index, epoch)
+				}
+			}
+		}
+		if w.rules[RuleMissedSyncSignature] {
+			// only the validators in the current committee have a participation
+			metrics.WatchedSyncParticipation.Reset()
+		}
+		if w.rules[RuleMissedSyncSignature] && len(positions) > 0 {
+			missed, blocks, err := w.missedSyncSignatures(firstSlot, lastSlot, positions)
+			if err != nil {
+				return alerts, err
+			}
+			for _, index := range indices {
+				if signatures := blocks * len(positions[index]); signatures > 0 {
+					label := metrics.ValidatorLabel(index)
+					metrics.WatchedSyncParticipation.WithLabelValues(label).Set(float64(signatures-missed[index]) / float64(signatures))
+				}
+				if missed[index] > 0 {
+					alert(RuleMissedSyncSignature, SeverityWarning, index, 0,
+						"validator %d missed %d of %d sync committee signatures in epoch %d", index, missed[index], blocks*len(positions[index]), epoch)
+				}
+			}
+		}
+	}
+
+	if w.rules[RuleBalanceDecrease] || w.rules[RuleSlashed] {
+		validatorResp, err := w.beacon.PublicKeysByValidatorIDs(indices, lastSlot)
+		if err != nil {
+			return alerts, fmt.Errorf("failed to fetch validators: %w", err)
+		}
+		// validators dropped from the watchlist go away
+		metrics.WatchedBalance.Reset()
+		for _, validator := range validatorResp.Data {
+			index, err := strconv.ParseInt(validator.Index, 10, 64)
+			if err != nil {
+				return alerts, fmt.Errorf("failed to parse validator index: %w", err)
+			}
+			if w.rules[RuleSlashed] && validator.Validator.Slashed && !w.slashed[index] {
+				alert(RuleSlashed, SeverityCritical, index, 0, "validator %d was slashed", index)
+			}
+			w.slashed[index] = validator.Validator.Slashed
+
+			balance, err := strconv.ParseInt(validator.Balance, 10, 64)
+			if err != nil {
+				return alerts, fmt.Errorf("failed to parse balance of validator %d: %w", index, err)
+			}
+			history := append(w.balances[index], balance)
+			if len(history) > w.balanceEpochs+1 {
+				history = history[len(history)-w.balanceEpochs-1:]
+			}
+			w.balances[index] = history
+			metrics.WatchedBalance.WithLabelValues(metrics.ValidatorLabel(index)).Set(float64(balance))
+			if len(history) <= w.balanceEpochs {
+				continue
+			}
+			decreasing := balance < history[0]
+			// only the start of a decrease is reported, not every epoch it goes on
+			if w.rules[RuleBalanceDecrease] && decreasing && !w.decreasing[index] {
+				alert(RuleBalanceDecrease, SeverityWarning, index, 0,
+					"balance of validator %d decreased by %d gwei over the last %d epochs", index, history[0]-balance, w.balanceEpochs)
+			}
+			w.decreasing[index] = decreasing
+		}
+	}
+	return alerts, nil
+}
+
+// missedSyncSignatures counts, per validator, the sync committee bits left unset in the blocks of
+// the slot range, along with the number of blocks. Missed slots carry no sync aggregate at all.
+func (w *Watcher) missedSyncSignatures(from, to int64, positions map[int64][]int) (map[int64]int, int, error) {
+	missed := make(map[int64]int)
+	blocks := 0
+	for slot := from; slot <= to; slot++ {
+		blockResp, err := w.beacon.FetchBlockResponse(slot)
+		if errors.Is(err, beaconadapter.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to fetch block for slot %d: %w", slot, err)
+		}
+		bits, err := hex.DecodeString(strings.TrimPrefix(blockResp.Data.Message.Body.SyncAggregate.SyncCommitteeBits, "0x"))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to decode sync committee bits of slot %d: %w", slot, err)
+		}
+		blocks++
+		for index, validatorPositions := range positions {
+			for _, position := range validatorPositions {
+				// bitvectors are little endian within each byte
+				if position/8 >= len(bits) || bits[position/8]&(1<<(position%8)) == 0 {
+					missed[index]++
+				}
+			}
+		}
+	}
+	return missed, blocks, nil
+}
@@ -0,0 +1,92 @@
+package watch
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/internal/beaconadapter"
+)
+
+// beaconStandIn serves epochs 256 and 257: validator 5 misses its proposal in slot 8193 and
+// two sync committee signatures in slot 8194, validator 9 is slashed and the balance of 5 drops in epoch 257.
+func beaconStandIn(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch p := r.URL.Path; {
+		case p == "/eth/v1/beacon/states/head/finality_checkpoints":
+			fmt.Fprint(w, `{"data":{"finalized":{"epoch":"250","root":"0x00"}}}`)
+		case p == "/eth/v1/beacon/headers/8193", p == "/eth/v2/beacon/blocks/8193":
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasPrefix(p, "/eth/v1/beacon/headers/"):
+			fmt.Fprint(w, `{"data":{"root":"0x01","canonical":true}}`)
+		case p == "/eth/v1/validator/duties/proposer/256":
+			fmt.Fprint(w, `{"data":[{"validator_index":"5","slot":"8193"}]}`)
+		case strings.HasSuffix(p, "/sync_committees"):
+			fmt.Fprint(w, `{"data":{"validators":["9","5","1","5"],"validator_aggregates":[["9","5","1","5"]]}}`)
+		case strings.HasPrefix(p, "/eth/v2/beacon/blocks/"):
+			bits := "0x0f"
+			if p == "/eth/v2/beacon/blocks/8194" {
+				bits = "0x05"
+			}
+			fmt.Fprintf(w, `{"data":{"message":{"body":{"sync_aggregate":{"sync_committee_bits":%q}}}}}`, bits)
+		case p == "/eth/v1/beacon/states/8223/validators", p == "/eth/v1/beacon/states/8255/validators":
+			balance := "32000000000"
+			if strings.Contains(p, "8255") {
+				balance = "31900000000"
+			}
+			fmt.Fprintf(w, `{"data":[{"index":"5","balance":%q,"validator":{"slashed":false}},`+
+				`{"index":"9","balance":"31000000000","validator":{"slashed":true}}]}`, balance)
+		default:
+			t.Errorf("unexpected request %v", p)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+}
+
+func TestCheckEpoch(t *testing.T) {
+	node := beaconStandIn(t)
+	defer node.Close()
+	client, err := beaconadapter.NewBeaconClient(node.URL, nil)
+	require.NoError(t, err)
+	watchlist, err := NewWatchlist(nil)
+	require.NoError(t, err)
+	require.NoError(t, watchlist.Add(5, 9))
+	watcher, err := NewWatcher(client, watchlist, nil, nil, 1)
+	require.NoError(t, err)
+
+	type fired struct {
+		rule  string
+		index int64
+	}
+	alerts, err := watcher.CheckEpoch(256)
+	require.NoError(t, err)
+	var got []fired
+	for _, alert := range alerts {
+		got = append(got, fired{alert.Rule, alert.ValidatorIndex})
+	}
+	require.Equal(t, []fired{
+		{RuleMissedProposal, 5},
+		{RuleSyncCommitteeEntered, 5},
+		{RuleSyncCommitteeEntered, 9},
+		{RuleMissedSyncSignature, 5},
+		{RuleSlashed, 9},
+	}, got)
+	require.Equal(t, int64(8193), alerts[0].Slot)
+	require.Equal(t, "validator 5 missed 2 of 62 sync committee signatures in epoch 256", alerts[3].Message)
+
+	// slashing is reported once, the balance decrease shows up with the second epoch
+	alerts, err = watcher.CheckEpoch(257)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.Equal(t, RuleBalanceDecrease, alerts[0].Rule)
+	require.Equal(t, "balance of validator 5 decreased by 100000000 gwei over the last 1 epochs", alerts[0].Message)
+}
+
+func TestNewWatcherUnknownRule(t *testing.T) {
+	_, err := NewWatcher(nil, nil, nil, []string{"missed_attestation"}, 3)
+	require.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package watch
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+
+	"ethereum-validator-api/internal/beaconadapter"
+	"ethereum-validator-api/internal/store"
+)
+
+var pubkeyPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{96}$`)
+
+// Watchlist is the set of validators the rules are checked for.
+// It's kept in the store when there is one, so validators added through the API survive restarts.
+type Watchlist struct {
+	mu      sync.RWMutex
+	indices map[int64]bool
+	store   *store.Store
+}
+
+// NewWatchlist loads the watchlist from the store, s may be nil to keep it in memory only.
+func NewWatchlist(s *store.Store) (*Watchlist, error) {
+	w := &Watchlist{indices: make(map[int64]bool), store: s}
+	if s == nil {
+		return w, nil
+	}
+	indices, err := s.GetWatchlist()
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indices {
+		w.indices[index] = true
+	}
+	return w, nil
+}
+
+// Indices returns the watched validator indices in ascending order.
+func (w *Watchlist) Indices() []int64 {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.sorted()
+}
+
+func (w *Watchlist) Contains(index int64) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.indices[index]
+}
+
+func (w *Watchlist) Add(indices ...int64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, index := range indices {
+		w.indices[index] = true
+	}
+	return w.save()
+}
+
+// Remove stops watching the validator, ok is false if it wasn't watched.
+func (w *Watchlist) Remove(index int64) (ok bool, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.indices[index] {
+		return false, nil
+	}
+	delete(w.indices, index)
+	return true, w.save()
+}
+
+func (w *Watchlist) save() error {
+	if w.store == nil {
+		return nil
+	}
+	return w.store.PutWatchlist(w.sorted())
+}
+
+func (w *Watchlist) sorted() []int64 {
+	indices := make([]int64, 0, len(w.indices))
+	for index := range w.indices {
+		indices = append(indices, index)
+	}
+	slices.Sort(indices)
+	return indices
+}
+
+// ResolveIndex turns a validator index or pubkey into an index, looking the pubkey up
+// in the registry first when there is one. Unknown pubkeys give beaconadapter.ErrNotFound.
+func ResolveIndex(client *beaconadapter.BeaconClient, registry *beaconadapter.Registry, id string) (int64, error) {
+	if !pubkeyPattern.MatchString(id) {
+		index, err := strconv.ParseInt(id, 10, 64)
+		if err != nil || index < 0 {
+			return 0, fmt.Errorf("invalid validator index or pubkey %q", id)
+		}
+		return index, nil
+	}
+	if registry != nil {
+		if index, ok := registry.Index(id); ok {
+			return index, nil
+		}
+	}
+	validatorResp, err := client.FetchValidator("head", id)
+	if err != nil {
+		return 0, err
+	}
+	index, err := strconv.ParseInt(validatorResp.Data.Index, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse validator index: %w", err)
+	}
+	return index, nil
+}
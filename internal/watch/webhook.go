@@ -0,0 +1,114 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"ethereum-validator-api/models"
+)
+
+const (
+	FormatJSON      = "json"
+	FormatSlack     = "slack"
+	FormatPagerDuty = "pagerduty"
+
+	constWebhookTimeout = 10 * time.Second
+	constAlertSource    = "ethereum-validator-api"
+)
+
+// Webhook is an alert receiver. Format picks the payload: the generic JSON alert,
+// a Slack incoming webhook message or a PagerDuty Events API v2 event.
+type Webhook struct {
+	URL    string `mapstructure:"url"`
+	Format string `mapstructure:"format"`
+	// RoutingKey is the PagerDuty integration key
+	RoutingKey string `mapstructure:"routing_key"`
+}
+
+// Notifier posts the alerts to all the webhooks.
+type Notifier struct {
+	webhooks   []Webhook
+	httpClient *http.Client
+}
+
+func NewNotifier(webhooks []Webhook, httpClient *http.Client) (*Notifier, error) {
+	for i := range webhooks {
+		switch webhooks[i].Format {
+		case "":
+			webhooks[i].Format = FormatJSON
+		case FormatJSON, FormatSlack:
+		case FormatPagerDuty:
+			if webhooks[i].RoutingKey == "" {
+				return nil, fmt.Errorf("webhook %s needs a routing key", webhooks[i].URL)
+			}
+		default:
+			return nil, fmt.Errorf("unknown webhook format %q", webhooks[i].Format)
+		}
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: constWebhookTimeout}
+	}
+	return &Notifier{webhooks: webhooks, httpClient: httpClient}, nil
+}
+
+// Notify sends the alert to every webhook, a failing webhook doesn't keep the others from getting it.
+func (n *Notifier) Notify(ctx context.Context, alert *models.Alert) error {
+	var errs []error
+	for _, webhook := range n.webhooks {
+		if err := n.send(ctx, webhook, alert); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify %s: %w", webhook.URL, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) send(ctx context.Context, webhook Webhook, alert *models.Alert) error {
+	body, err := json.Marshal(webhookPayload(webhook, alert))
+	if err != nil {
+		return fmt.Errorf("failed to encode the payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected HTTP status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func webhookPayload(webhook Webhook, alert *models.Alert) any {
+	switch webhook.Format {
+	case FormatSlack:
+		return map[string]string{"text": fmt.Sprintf("[%s] %s", alert.Severity, alert.Message)}
+	case FormatPagerDuty:
+		return map[string]any{
+			"routing_key":  webhook.RoutingKey,
+			"event_action": "trigger",
+			// the same alert fired twice is a single incident
+			"dedup_key": fmt.Sprintf("%s/%d/%d", alert.Rule, alert.ValidatorIndex, alert.Epoch),
+			"payload": map[string]any{
+				"summary":        alert.Message,
+				"source":         constAlertSource,
+				"severity":       alert.Severity,
+				"timestamp":      alert.Time.Format(time.RFC3339),
+				"component":      fmt.Sprintf("validator %d", alert.ValidatorIndex),
+				"group":          alert.Rule,
+				"custom_details": alert,
+			},
+		}
+	default:
+		return alert
+	}
+}
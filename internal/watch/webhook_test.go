@@ -0,0 +1,75 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"ethereum-validator-api/models"
+)
+
+func TestNotifier(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]map[string]any)
+	// a local webhook receiver
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal(body, &payload))
+		mu.Lock()
+		received[r.URL.Path] = payload
+		mu.Unlock()
+	}))
+	defer receiver.Close()
+
+	notifier, err := NewNotifier([]Webhook{
+		{URL: receiver.URL + "/broken"},
+		{URL: receiver.URL + "/json"},
+		{URL: receiver.URL + "/slack", Format: FormatSlack},
+		{URL: receiver.URL + "/pagerduty", Format: FormatPagerDuty, RoutingKey: "key"},
+	}, nil)
+	require.NoError(t, err)
+	alert := &models.Alert{
+		Rule:           RuleSlashed,
+		Severity:       SeverityCritical,
+		ValidatorIndex: 9,
+		Epoch:          256,
+		Message:        "validator 9 was slashed",
+		Time:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	err = notifier.Notify(context.Background(), alert)
+	require.ErrorContains(t, err, "/broken")
+
+	require.Equal(t, map[string]any{
+		"rule": "slashed", "severity": "critical", "validator_index": float64(9), "epoch": float64(256),
+		"message": "validator 9 was slashed", "time": "2024-01-01T00:00:00Z",
+	}, received["/json"])
+	require.Equal(t, map[string]any{"text": "[critical] validator 9 was slashed"}, received["/slack"])
+	pagerduty := received["/pagerduty"]
+	require.Equal(t, "key", pagerduty["routing_key"])
+	require.Equal(t, "trigger", pagerduty["event_action"])
+	require.Equal(t, "slashed/9/256", pagerduty["dedup_key"])
+	payload := pagerduty["payload"].(map[string]any)
+	require.Equal(t, "validator 9 was slashed", payload["summary"])
+	require.Equal(t, "critical", payload["severity"])
+}
+
+func TestNewNotifierValidation(t *testing.T) {
+	_, err := NewNotifier([]Webhook{{URL: "http://localhost", Format: "email"}}, nil)
+	require.Error(t, err)
+	_, err = NewNotifier([]Webhook{{URL: "http://localhost", Format: FormatPagerDuty}}, nil)
+	require.Error(t, err)
+}
@@ -1,12 +1,328 @@
 package models
 
+import "time"
+
+// BlockReward is the reward of a block. It may still change while the block is not finalized.
 type BlockReward struct {
-	Status bool  `json:"status"`
-	Reward int64 `json:"reward"`
+	Status              bool  `json:"status"`
+	Reward              int64 `json:"reward"`
+	ExecutionOptimistic bool  `json:"execution_optimistic"`
+	Finalized           bool  `json:"finalized"`
+	// Mode is the mode the reward was computed in, light or full
+	Mode string `json:"mode,omitempty"`
+	// Fiat is the reward in the requested currency, unset when none was requested or there is no price
+	Fiat *FiatValue `json:"fiat,omitempty"`
+}
+
+// FiatValue is a reward valued at the ETH price of the day of its block.
+type FiatValue struct {
+	Currency string `json:"currency" parquet:"currency"`
+	// Date is the UTC day of the price, YYYY-MM-DD
+	Date string `json:"date" parquet:"date"`
+	// Price is the price of one ETH on Date
+	Price  float64 `json:"price" parquet:"price"`
+	Reward float64 `json:"reward" parquet:"reward"`
+}
+
+// RewardBreakdown is the full computation behind a BlockReward. All amounts are in gwei.
+type RewardBreakdown struct {
+	Slot            int64  `json:"slot" parquet:"slot"`
+	BlockRoot       string `json:"block_root,omitempty" parquet:"block_root"`
+	ParentRoot      string `json:"parent_root" parquet:"parent_root"`
+	BlockNumber     int64  `json:"block_number" parquet:"block_number"`
+	ProposerIndex   int64  `json:"proposer_index" parquet:"proposer_index"`
+	FeeRecipient    string `json:"fee_recipient" parquet:"fee_recipient"`
+	Mode            string `json:"mode" parquet:"mode"`
+	Mev             bool   `json:"mev" parquet:"mev"`
+	TransactionFees int64  `json:"transaction_fees" parquet:"transaction_fees"`
+	BurntFees       int64  `json:"burnt_fees" parquet:"burnt_fees"`
+	MevPayment      int64  `json:"mev_payment" parquet:"mev_payment"`
+	// the blobs of the block, none before Deneb. Their fees are burnt and aren't part of the reward.
+	Blobs       int64 `json:"blobs" parquet:"blobs"`
+	BlobGasUsed int64 `json:"blob_gas_used" parquet:"blob_gas_used"`
+	// BlobBaseFee is the price of the blob gas in wei
+	BlobBaseFee         int64 `json:"blob_base_fee" parquet:"blob_base_fee"`
+	BlobFees            int64 `json:"blob_fees" parquet:"blob_fees"`
+	ConsensusRewards    int64 `json:"consensus_rewards" parquet:"consensus_rewards"`
+	Reward              int64 `json:"reward" parquet:"reward"`
+	Finalized           bool  `json:"finalized" parquet:"finalized"`
+	ExecutionOptimistic bool  `json:"execution_optimistic" parquet:"execution_optimistic"`
+	// ComputedAt is unknown for the rewards stored before it was recorded
+	ComputedAt *time.Time `json:"computed_at,omitempty" parquet:"computed_at,optional"`
+	// Fiat is only set on the way out, when a currency is requested, it isn't stored
+	Fiat *FiatValue `json:"fiat,omitempty" parquet:"fiat,optional"`
+}
+
+func (b *RewardBreakdown) BlockReward() *BlockReward {
+	return &BlockReward{
+		Status:              b.Mev,
+		Reward:              b.Reward,
+		ExecutionOptimistic: b.ExecutionOptimistic,
+		Finalized:           b.Finalized,
+		Fiat:                b.Fiat,
+	}
 }
 
 type SyncDuties struct {
-	Validators []string `json:"validators"`
+	Validators          []string `json:"validators"`
+	ExecutionOptimistic bool     `json:"execution_optimistic"`
+	Finalized           bool     `json:"finalized"`
+}
+
+type SyncDutiesDetail struct {
+	Validators          []SyncCommitteeMember `json:"validators"`
+	ExecutionOptimistic bool                  `json:"execution_optimistic"`
+	Finalized           bool                  `json:"finalized"`
+}
+
+type SyncCommitteeMember struct {
+	Index             int64  `json:"index"`
+	Pubkey            string `json:"pubkey"`
+	Status            string `json:"status"`
+	Balance           int64  `json:"balance"`
+	EffectiveBalance  int64  `json:"effective_balance"`
+	Slashed           bool   `json:"slashed"`
+	SubcommitteeIndex int    `json:"subcommittee_index"`
+}
+
+// Validator describes a validator at some state. Epochs are null while not scheduled yet.
+type Validator struct {
+	Index                      int64  `json:"index"`
+	Pubkey                     string `json:"pubkey"`
+	Status                     string `json:"status"`
+	Balance                    int64  `json:"balance"`
+	EffectiveBalance           int64  `json:"effective_balance"`
+	Slashed                    bool   `json:"slashed"`
+	ActivationEligibilityEpoch *int64 `json:"activation_eligibility_epoch"`
+	ActivationEpoch            *int64 `json:"activation_epoch"`
+	ExitEpoch                  *int64 `json:"exit_epoch"`
+	WithdrawableEpoch          *int64 `json:"withdrawable_epoch"`
+	WithdrawalCredentials      string `json:"withdrawal_credentials"`
+	WithdrawalCredentialsType  string `json:"withdrawal_credentials_type"`
+	WithdrawalAddress          string `json:"withdrawal_address,omitempty"`
+	ExecutionOptimistic        bool   `json:"execution_optimistic"`
+	Finalized                  bool   `json:"finalized"`
+}
+
+// BlockRewards is a page of the rewards of a slot range or the rewards of a list of slots.
+type BlockRewards struct {
+	Rewards []SlotBlockReward `json:"rewards"`
+	// NextFromSlot is the from_slot of the next page of a range, unset on the last one
+	NextFromSlot *int64 `json:"next_from_slot,omitempty"`
+}
+
+// SlotBlockReward is the reward of a slot of a batch, or why there is none.
+type SlotBlockReward struct {
+	Slot   int64        `json:"slot"`
+	Reward *BlockReward `json:"reward,omitempty"`
+	// Missed is set for the slots without a block
+	Missed bool   `json:"missed,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// SlotBlockRewardRow is a SlotBlockReward flattened for the CSV and NDJSON answers.
+// The reward fields are empty when the slot has no reward.
+type SlotBlockRewardRow struct {
+	Slot                int64      `json:"slot"`
+	Missed              bool       `json:"missed"`
+	Error               string     `json:"error"`
+	Mev                 *bool      `json:"mev"`
+	Reward              *int64     `json:"reward"`
+	ExecutionOptimistic *bool      `json:"execution_optimistic"`
+	Finalized           *bool      `json:"finalized"`
+	Mode                string     `json:"mode"`
+	Fiat                *FiatValue `json:"fiat"`
+}
+
+func (r *SlotBlockReward) Row() SlotBlockRewardRow {
+	row := SlotBlockRewardRow{Slot: r.Slot, Missed: r.Missed, Error: r.Error}
+	if r.Reward != nil {
+		row.Mev = &r.Reward.Status
+		row.Reward = &r.Reward.Reward
+		row.ExecutionOptimistic = &r.Reward.ExecutionOptimistic
+		row.Finalized = &r.Reward.Finalized
+		row.Mode = r.Reward.Mode
+		row.Fiat = r.Reward.Fiat
+	}
+	return row
+}
+
+// BlockRewardsRequest asks for the rewards of a list of slots.
+type BlockRewardsRequest struct {
+	Slots []int64 `json:"slots" binding:"required"`
+}
+
+// EpochSummary sums up the blocks of an epoch. All amounts are in gwei.
+type EpochSummary struct {
+	Epoch         int64   `json:"epoch"`
+	FromSlot      int64   `json:"from_slot"`
+	ToSlot        int64   `json:"to_slot"`
+	ProposedSlots int     `json:"proposed_slots"`
+	MissedSlots   []int64 `json:"missed_slots"`
+	// the EL rewards of the proposers: TransactionFees - BurntFees + MevPayments
+	TransactionFees  int64 `json:"transaction_fees"`
+	BurntFees        int64 `json:"burnt_fees"`
+	MevPayments      int64 `json:"mev_payments"`
+	ExecutionRewards int64 `json:"execution_rewards"`
+	// Blobs and BlobFees sum up the blobs of the proposed blocks, their fees are burnt
+	Blobs    int64 `json:"blobs"`
+	BlobFees int64 `json:"blob_fees"`
+	// ConsensusProposerRewards are the CL rewards of the proposers for their blocks
+	ConsensusProposerRewards int64 `json:"consensus_proposer_rewards"`
+	MevBlocks                int   `json:"mev_blocks"`
+	// MevBoostShare is the share of the proposed blocks built through MEV-boost
+	MevBoostShare float64 `json:"mev_boost_share"`
+	// ParticipationRate is the share of the attestation duties fulfilled, from the attestations in the blocks
+	ParticipationRate   float64 `json:"participation_rate"`
+	Finalized           bool    `json:"finalized"`
+	SyncCommitteePeriod int64   `json:"sync_committee_period"`
+}
+
+type MissedSlots struct {
+	FromSlot int64        `json:"from_slot"`
+	ToSlot   int64        `json:"to_slot"`
+	Slots    []MissedSlot `json:"slots"`
+}
+
+// MissedSlot is a slot without a block. Finalized is false while the block may still be reorged in.
+type MissedSlot struct {
+	Slot          int64 `json:"slot"`
+	Epoch         int64 `json:"epoch"`
+	ProposerIndex int64 `json:"proposer_index"`
+	Finalized     bool  `json:"finalized"`
+}
+
+// Withdrawal is a withdrawal of a validator balance to its execution address.
+// Kind is partial for the skims of the balance above the max, full for the exits.
+type Withdrawal struct {
+	Index          int64  `json:"index"`
+	Slot           int64  `json:"slot"`
+	ValidatorIndex int64  `json:"validator_index"`
+	Address        string `json:"address"`
+	// Amount is in gwei
+	Amount int64  `json:"amount"`
+	Kind   string `json:"kind" enums:"partial,full"`
+}
+
+// SlotWithdrawals are the withdrawals of a block.
+type SlotWithdrawals struct {
+	Slot                int64        `json:"slot"`
+	BlockNumber         int64        `json:"block_number"`
+	Withdrawals         []Withdrawal `json:"withdrawals"`
+	ExecutionOptimistic bool         `json:"execution_optimistic"`
+	Finalized           bool         `json:"finalized"`
+}
+
+// Blob is a blob of a block, without its data.
+type Blob struct {
+	Index         int64  `json:"index"`
+	KzgCommitment string `json:"kzg_commitment"`
+	// VersionedHash is the hash the blob transactions reference the blob by
+	VersionedHash string `json:"versioned_hash"`
+	// KzgProof is unset once the node pruned the sidecars of the block, after about 18 days
+	KzgProof string `json:"kzg_proof,omitempty"`
+}
+
+// SlotBlobs are the blobs of a block and their fees, which are burnt.
+type SlotBlobs struct {
+	Slot        int64 `json:"slot"`
+	BlobCount   int64 `json:"blob_count"`
+	BlobGasUsed int64 `json:"blob_gas_used"`
+	// BlobBaseFee is the price of the blob gas in wei
+	BlobBaseFee int64 `json:"blob_base_fee"`
+	// BlobFees are in gwei
+	BlobFees            int64  `json:"blob_fees"`
+	Blobs               []Blob `json:"blobs"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+	Finalized           bool   `json:"finalized"`
+}
+
+// Withdrawals are the withdrawals of a slot range, the missed slots have none.
+type Withdrawals struct {
+	FromSlot    int64        `json:"from_slot"`
+	ToSlot      int64        `json:"to_slot"`
+	Withdrawals []Withdrawal `json:"withdrawals"`
+	// Finalized is true when the blocks of all the slots are finalized
+	Finalized bool `json:"finalized"`
+}
+
+type Watchlist struct {
+	Validators []int64 `json:"validators"`
+}
+
+// WatchlistRequest adds validators to the watchlist by index or pubkey.
+type WatchlistRequest struct {
+	Validators []string `json:"validators" binding:"required"`
+}
+
+// Alert is fired by a watchlist rule, it's also the generic webhook payload.
+type Alert struct {
+	Rule           string    `json:"rule"`
+	Severity       string    `json:"severity"`
+	ValidatorIndex int64     `json:"validator_index"`
+	Epoch          int64     `json:"epoch"`
+	Slot           int64     `json:"slot,omitempty"`
+	Message        string    `json:"message"`
+	Time           time.Time `json:"time"`
+}
+
+// Status is the state of the upstreams as of the last check.
+type Status struct {
+	Ready bool `json:"ready"`
+	// Reasons tells why the service isn't ready
+	Reasons     []string         `json:"reasons,omitempty"`
+	CurrentSlot int64            `json:"current_slot"`
+	Upstreams   []UpstreamStatus `json:"upstreams"`
+}
+
+// UpstreamStatus is the head and health of an upstream node. Heads are slots for the beacon node
+// and block numbers for the execution node.
+type UpstreamStatus struct {
+	Name      string `json:"name"`
+	Reachable bool   `json:"reachable"`
+	Head      int64  `json:"head"`
+	// SyncDistance is how far the node is behind the head it knows of
+	SyncDistance int64 `json:"sync_distance"`
+	IsSyncing    bool  `json:"is_syncing"`
+	// HeadLag is how many slots the beacon head is behind the wall clock
+	HeadLag       int64      `json:"head_lag,omitempty"`
+	LatencyMs     int64      `json:"latency_ms"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastErrorTime *time.Time `json:"last_error_time,omitempty"`
+	CheckedAt     time.Time  `json:"checked_at"`
+}
+
+// Readiness is the answer of the readiness probe.
+type Readiness struct {
+	Ready   bool     `json:"ready"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// Envelope wraps every v2 result.
+type Envelope struct {
+	Data any   `json:"data"`
+	Meta *Meta `json:"meta"`
+}
+
+// Meta tells where a v2 result comes from. The slot fields are left out for results that aren't about a slot.
+type Meta struct {
+	Slot                *int64     `json:"slot,omitempty"`
+	Epoch               *int64     `json:"epoch,omitempty"`
+	Finalized           *bool      `json:"finalized,omitempty"`
+	ExecutionOptimistic *bool      `json:"execution_optimistic,omitempty"`
+	SourceNode          string     `json:"source_node,omitempty"`
+	ComputedAt          *time.Time `json:"computed_at,omitempty"`
+}
+
+// ErrorEnvelope is the body of the v2 errors.
+type ErrorEnvelope struct {
+	Error ErrorDetail `json:"error"`
+	Meta  *Meta       `json:"meta"`
+}
+
+type ErrorDetail struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
 }
 
 type Error struct {